@@ -0,0 +1,196 @@
+// Package auth replaces the mock AuthMiddleware/AdminMiddleware in
+// main.go with real JWT verification, built on
+// github.com/appleboy/gin-jwt/v2. Login issues a single signed token;
+// /auth/refresh exchanges a token issued within the last RefreshTTL for a
+// new one, and no separate refresh token is issued. It extracts a "roles"
+// claim for authorization, and supports revoking a token by jti before it
+// naturally expires.
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	ginjwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// IdentityKey is the claim gin-jwt stores the authenticated user under;
+// handlers read it back via ginjwt.ExtractClaims(c)[IdentityKey].
+const IdentityKey = "id"
+
+// Identity is the principal gin-jwt threads through PayloadFunc /
+// IdentityHandler / Authorizator.
+type Identity struct {
+	UserID string
+	Roles  []string
+}
+
+// Config configures the token issuer. SigningAlgorithm is "HS256" (Secret
+// required) or "RS256" (PrivKeyFile/PubKeyFile, or JWKSURL to resolve the
+// public key remotely).
+type Config struct {
+	Realm            string
+	SigningAlgorithm string
+	Secret           []byte
+	PrivKeyFile      string
+	PubKeyFile       string
+	JWKSURL          string
+	AccessTTL        time.Duration
+	RefreshTTL       time.Duration
+	Blocklist        Blocklist
+	// Authenticate validates credentials submitted to /auth/login and
+	// returns the identity to embed in the issued tokens.
+	Authenticate func(c *gin.Context, username, password string) (Identity, error)
+}
+
+type loginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// New builds the gin-jwt middleware used both to protect routes
+// (MiddlewareFunc) and to serve /auth/login and /auth/refresh
+// (LoginHandler, RefreshHandler).
+func New(cfg Config) (*ginjwt.GinJWTMiddleware, error) {
+	if cfg.AccessTTL == 0 {
+		cfg.AccessTTL = 15 * time.Minute
+	}
+	if cfg.RefreshTTL == 0 {
+		cfg.RefreshTTL = 7 * 24 * time.Hour
+	}
+	if cfg.SigningAlgorithm == "" {
+		cfg.SigningAlgorithm = "HS256"
+	}
+
+	pubKeyBytes, err := resolvePublicKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	mw := &ginjwt.GinJWTMiddleware{
+		Realm:            cfg.Realm,
+		SigningAlgorithm: cfg.SigningAlgorithm,
+		Key:              cfg.Secret,
+		PrivKeyFile:      cfg.PrivKeyFile,
+		PubKeyBytes:      pubKeyBytes,
+		Timeout:          cfg.AccessTTL,
+		MaxRefresh:       cfg.RefreshTTL,
+		IdentityKey:      IdentityKey,
+
+		PayloadFunc: func(data interface{}) ginjwt.MapClaims {
+			identity, ok := data.(Identity)
+			if !ok {
+				return ginjwt.MapClaims{}
+			}
+			return ginjwt.MapClaims{
+				IdentityKey: identity.UserID,
+				"roles":     identity.Roles,
+				"jti":       uuid.New().String(),
+			}
+		},
+
+		IdentityHandler: func(c *gin.Context) interface{} {
+			claims := ginjwt.ExtractClaims(c)
+			userID, ok := claims[IdentityKey].(string)
+			if !ok {
+				return nil
+			}
+			return Identity{
+				UserID: userID,
+				Roles:  toStringSlice(claims["roles"]),
+			}
+		},
+
+		Authenticator: func(c *gin.Context) (interface{}, error) {
+			var req loginRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				return nil, ginjwt.ErrMissingLoginValues
+			}
+			if cfg.Authenticate == nil {
+				return nil, ginjwt.ErrFailedAuthentication
+			}
+			return cfg.Authenticate(c, req.Username, req.Password)
+		},
+
+		Authorizator: func(data interface{}, c *gin.Context) bool {
+			if cfg.Blocklist != nil {
+				claims := ginjwt.ExtractClaims(c)
+				jti, _ := claims["jti"].(string)
+				if jti != "" {
+					revoked, err := cfg.Blocklist.IsRevoked(jti)
+					if err != nil || revoked {
+						return false
+					}
+				}
+			}
+			_, ok := data.(Identity)
+			return ok
+		},
+
+		Unauthorized: func(c *gin.Context, code int, message string) {
+			c.JSON(code, gin.H{"error": message})
+		},
+
+		TokenLookup:   "header: Authorization",
+		TokenHeadName: "Bearer",
+		TimeFunc:      time.Now,
+	}
+
+	if err := mw.MiddlewareInit(); err != nil {
+		return nil, err
+	}
+	return mw, nil
+}
+
+// RequireRole authorizes a request only if the caller's token carries
+// role. It must be mounted after MiddlewareFunc() so claims are already
+// extracted.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := ginjwt.ExtractClaims(c)
+		for _, r := range toStringSlice(claims["roles"]) {
+			if r == role {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "auth: role not permitted"})
+		c.Abort()
+	}
+}
+
+// Revoke blocklists the jti carried by the request's token for the
+// remainder of its validity, used by a logout endpoint.
+func Revoke(c *gin.Context, blocklist Blocklist) error {
+	if blocklist == nil {
+		return nil
+	}
+	claims := ginjwt.ExtractClaims(c)
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return nil
+	}
+	expUnix, _ := claims["exp"].(float64)
+	ttl := time.Until(time.Unix(int64(expUnix), 0))
+	if ttl <= 0 {
+		return nil
+	}
+	return blocklist.Revoke(jti, ttl)
+}
+
+func toStringSlice(v interface{}) []string {
+	raw, ok := v.([]interface{})
+	if !ok {
+		if s, ok := v.([]string); ok {
+			return s
+		}
+		return nil
+	}
+	out := make([]string, len(raw))
+	for i, r := range raw {
+		out[i], _ = r.(string)
+	}
+	return out
+}
@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ginjwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func newTestMiddleware(t *testing.T) *ginjwt.GinJWTMiddleware {
+	t.Helper()
+	mw, err := New(Config{
+		Realm:            "test",
+		SigningAlgorithm: "HS256",
+		Secret:           []byte("test-secret"),
+		AccessTTL:        time.Minute,
+		Authenticate: func(c *gin.Context, username, password string) (Identity, error) {
+			if username == "customer" && password == "pw" {
+				return Identity{UserID: "user_123", Roles: []string{"customer"}}, nil
+			}
+			if username == "admin" && password == "pw" {
+				return Identity{UserID: "user_admin", Roles: []string{"admin"}}, nil
+			}
+			return Identity{}, ginjwt.ErrFailedAuthentication
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return mw
+}
+
+func router(mw *ginjwt.GinJWTMiddleware) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/auth/login", mw.LoginHandler)
+	protected := r.Group("/api/v1/orders")
+	protected.Use(mw.MiddlewareFunc())
+	protected.GET("", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	admin := r.Group("/internal")
+	admin.Use(mw.MiddlewareFunc(), RequireRole("admin"))
+	admin.GET("/admin/orders", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	return r
+}
+
+func login(t *testing.T, r *gin.Engine, username, password string) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+		strings.NewReader(`{"username":"`+username+`","password":"`+password+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("login failed: %d %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode login response: %v", err)
+	}
+	return body.Token
+}
+
+func TestRoleMismatchedTokenRejected(t *testing.T) {
+	mw := newTestMiddleware(t)
+	r := router(mw)
+
+	token := login(t, r, "customer", "pw")
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/admin/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for customer calling admin route, got %d", w.Code)
+	}
+}
+
+func TestExpiredTokenRejected(t *testing.T) {
+	mw, err := New(Config{
+		Realm:            "test",
+		SigningAlgorithm: "HS256",
+		Secret:           []byte("test-secret"),
+		AccessTTL:        -time.Minute, // already expired by the time it's checked
+		Authenticate: func(c *gin.Context, username, password string) (Identity, error) {
+			return Identity{UserID: "user_123", Roles: []string{"customer"}}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r := router(mw)
+	token := login(t, r, "customer", "pw")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for expired token, got %d", w.Code)
+	}
+}
+
+func TestNonStringIdentityClaimRejectedNotPanicked(t *testing.T) {
+	secret := []byte("test-secret")
+	mw, err := New(Config{
+		Realm:            "test",
+		SigningAlgorithm: "HS256",
+		Secret:           secret,
+		AccessTTL:        time.Minute,
+		Authenticate: func(c *gin.Context, username, password string) (Identity, error) {
+			return Identity{UserID: "user_123", Roles: []string{"customer"}}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r := router(mw)
+
+	// A validly-signed token whose identity claim is a number instead of
+	// a string, e.g. from a differently-shaped external issuer.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		IdentityKey: 12345,
+		"roles":     []string{"customer"},
+		"exp":       time.Now().Add(time.Minute).Unix(),
+		"orig_iat":  time.Now().Unix(),
+	})
+	token, err := forged.SignedString(secret)
+	if err != nil {
+		t.Fatalf("sign forged token: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-string identity claim, got %d", w.Code)
+	}
+}
+
+// fakeBlocklist is an in-memory Blocklist for tests; RedisBlocklist is
+// exercised against a real Redis elsewhere.
+type fakeBlocklist struct {
+	revoked map[string]bool
+}
+
+func newFakeBlocklist() *fakeBlocklist { return &fakeBlocklist{revoked: map[string]bool{}} }
+
+func (b *fakeBlocklist) IsRevoked(jti string) (bool, error) { return b.revoked[jti], nil }
+
+func (b *fakeBlocklist) Revoke(jti string, ttl time.Duration) error {
+	b.revoked[jti] = true
+	return nil
+}
+
+func TestRevokedTokenRejectedOnNextRequest(t *testing.T) {
+	blocklist := newFakeBlocklist()
+	mw, err := New(Config{
+		Realm:            "test",
+		SigningAlgorithm: "HS256",
+		Secret:           []byte("test-secret"),
+		AccessTTL:        time.Minute,
+		Blocklist:        blocklist,
+		Authenticate: func(c *gin.Context, username, password string) (Identity, error) {
+			return Identity{UserID: "user_123", Roles: []string{"customer"}}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/auth/login", mw.LoginHandler)
+	protected := r.Group("/api/v1/orders")
+	protected.Use(mw.MiddlewareFunc())
+	protected.GET("", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	r.POST("/api/v1/auth/logout", mw.MiddlewareFunc(), func(c *gin.Context) {
+		if err := Revoke(c, blocklist); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+	})
+
+	token := login(t, r, "customer", "pw")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 before logout, got %d", w.Code)
+	}
+
+	logoutReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", nil)
+	logoutReq.Header.Set("Authorization", "Bearer "+token)
+	logoutW := httptest.NewRecorder()
+	r.ServeHTTP(logoutW, logoutReq)
+	if logoutW.Code != http.StatusOK {
+		t.Fatalf("logout failed: %d %s", logoutW.Code, logoutW.Body.String())
+	}
+
+	req2 := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req2.Header.Set("Authorization", "Bearer "+token)
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, req2)
+	if w2.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for revoked token after logout, got %d", w2.Code)
+	}
+}
+
+func TestTamperedTokenRejected(t *testing.T) {
+	mw := newTestMiddleware(t)
+	r := router(mw)
+	token := login(t, r, "customer", "pw")
+
+	tampered := token[:len(token)-4] + "abcd"
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+tampered)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for tampered token, got %d", w.Code)
+	}
+}
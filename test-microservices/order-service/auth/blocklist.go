@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Blocklist tracks revoked token IDs (jti) so a logout or forced
+// revocation takes effect before the token's natural expiry.
+type Blocklist interface {
+	IsRevoked(jti string) (bool, error)
+	Revoke(jti string, ttl time.Duration) error
+}
+
+// RedisBlocklist stores revoked jti's as keys with a TTL matching the
+// remaining token lifetime, so entries expire on their own.
+type RedisBlocklist struct {
+	client *redis.Client
+}
+
+func NewRedisBlocklist(client *redis.Client) *RedisBlocklist {
+	return &RedisBlocklist{client: client}
+}
+
+func (b *RedisBlocklist) key(jti string) string {
+	return "auth:revoked:" + jti
+}
+
+func (b *RedisBlocklist) IsRevoked(jti string) (bool, error) {
+	n, err := b.client.Exists(context.Background(), b.key(jti)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (b *RedisBlocklist) Revoke(jti string, ttl time.Duration) error {
+	return b.client.Set(context.Background(), b.key(jti), "1", ttl).Err()
+}
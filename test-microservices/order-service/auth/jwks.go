@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+)
+
+// resolvePublicKey returns the RS256 public key bytes gin-jwt should
+// verify against. PubKeyFile wins if set; otherwise, for RS256 with a
+// JWKSURL configured, the key is fetched from the JWKS endpoint (see
+// jwks_fetch.go). HS256 deployments have no public key and return nil.
+func resolvePublicKey(cfg Config) ([]byte, error) {
+	if cfg.SigningAlgorithm != "RS256" {
+		return nil, nil
+	}
+	if cfg.PubKeyFile != "" {
+		pem, err := os.ReadFile(cfg.PubKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("auth: read PubKeyFile: %w", err)
+		}
+		return pem, nil
+	}
+	if cfg.JWKSURL != "" {
+		return FetchJWKSPublicKeyPEM(cfg.JWKSURL)
+	}
+	return nil, fmt.Errorf("auth: RS256 requires PubKeyFile or JWKSURL")
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// FetchJWKSPublicKeyPEM retrieves the JWKS document at url and returns
+// the first RSA signing key, PEM-encoded the same way a PubKeyFile's
+// contents would be. Deployments with key rotation should prefer
+// PubKeyFile with a sidecar that refreshes the file instead of relying
+// on a single startup fetch.
+func FetchJWKSPublicKeyPEM(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("auth: fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("auth: decode JWKS: %w", err)
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("auth: JWKS has no keys")
+	}
+
+	var key *jwk
+	for i := range set.Keys {
+		if set.Keys[i].Kty == "RSA" && (set.Keys[i].Use == "" || set.Keys[i].Use == "sig") {
+			key = &set.Keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("auth: JWKS has no RSA signing key")
+	}
+	pubKey, err := key.rsaPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("auth: marshal JWKS key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("auth: decode JWKS modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("auth: decode JWKS exponent: %w", err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Package dto holds the typed request bodies handlers bind into via
+// problem.BindJSON, separate from the store package's persisted models
+// so request-shape validation (binding/validator tags) doesn't leak into
+// what gets saved to the database.
+package dto
+
+import "order-service/store"
+
+// AddToCartRequest is the body for POST /api/v1/cart/items.
+type AddToCartRequest struct {
+	ProductID string `json:"productId" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required,min=1"`
+}
+
+// UpdateCartItemRequest is the body for PUT /api/v1/cart/items/:itemId.
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity" binding:"required,min=1"`
+}
+
+// BulkCancelOrdersRequest is the body for POST /internal/admin/orders/bulk-cancel.
+type BulkCancelOrdersRequest struct {
+	OrderIDs []string `json:"orderIds" binding:"required,min=1"`
+}
+
+// CreateOrderRequest is the body for POST /api/v1/orders.
+type CreateOrderRequest struct {
+	Items           []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	ShippingAddress string             `json:"shippingAddress" binding:"required"`
+}
+
+// OrderItemRequest is one line item of a CreateOrderRequest.
+type OrderItemRequest struct {
+	ProductID string  `json:"productId" binding:"required"`
+	Quantity  int     `json:"quantity" binding:"required,min=1"`
+	Price     float64 `json:"price" binding:"required,gt=0"`
+}
+
+// UpdateOrderStatusRequest is the body for PUT /internal/admin/orders/:orderId/status.
+type UpdateOrderStatusRequest struct {
+	Status store.OrderStatus `json:"status" binding:"required,oneof=pending confirmed processing shipped delivered cancelled"`
+}
+
+// AllowedTransitions enumerates which OrderStatus values an order may
+// move to from its current status; orders don't move backwards and a
+// cancelled order is terminal.
+var AllowedTransitions = map[store.OrderStatus][]store.OrderStatus{
+	store.StatusPending:    {store.StatusConfirmed, store.StatusCancelled},
+	store.StatusConfirmed:  {store.StatusProcessing, store.StatusCancelled},
+	store.StatusProcessing: {store.StatusShipped, store.StatusCancelled},
+	store.StatusShipped:    {store.StatusDelivered},
+	store.StatusDelivered:  {},
+	store.StatusCancelled:  {},
+}
+
+// CanTransition reports whether an order may move from `from` to `to`.
+func CanTransition(from, to store.OrderStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range AllowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CalculateShippingRequest is the body for POST /api/v1/shipping/calculate.
+type CalculateShippingRequest struct {
+	From     AddressRequest `json:"from" binding:"required"`
+	To       AddressRequest `json:"to" binding:"required"`
+	Parcel   ParcelRequest  `json:"parcel" binding:"required"`
+	Subtotal float64        `json:"subtotal" binding:"required,gt=0"`
+	TaxRate  float64        `json:"taxRate" binding:"gte=0"`
+}
+
+// AddressRequest validates a shipping address; Country must be an
+// ISO 3166-1 alpha-2 code.
+type AddressRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Line1      string `json:"line1" binding:"required"`
+	Line2      string `json:"line2"`
+	City       string `json:"city" binding:"required"`
+	State      string `json:"state"`
+	PostalCode string `json:"postalCode" binding:"required"`
+	Country    string `json:"country" binding:"required,len=2"`
+}
+
+// ParcelRequest validates the package dimensions a rate or label request needs.
+type ParcelRequest struct {
+	WeightKg float64 `json:"weightKg" binding:"required,gt=0"`
+	LengthCm float64 `json:"lengthCm" binding:"gt=0"`
+	WidthCm  float64 `json:"widthCm" binding:"gt=0"`
+	HeightCm float64 `json:"heightCm" binding:"gt=0"`
+}
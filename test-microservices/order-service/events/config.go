@@ -0,0 +1,59 @@
+package events
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// Config describes the Kafka brokers, topic names, and consumer-group
+// naming the order-service uses for both publishing and consuming.
+type Config struct {
+	Brokers []string
+
+	// OrderEventsTopic carries every order/cart lifecycle event, keyed by
+	// orderId so a given order's events stay in partition order.
+	OrderEventsTopic string
+
+	PaymentsTopic  string
+	ShippingTopic  string
+	InventoryTopic string
+
+	ConsumerGroup string
+
+	OutboxPollInterval time.Duration
+	OutboxBatchSize    int
+}
+
+// LoadConfig reads broker/topic configuration from ORDER_KAFKA_* env
+// vars, falling back to sane local-dev defaults.
+func LoadConfig() Config {
+	return Config{
+		Brokers:            splitCSV(envOr("ORDER_KAFKA_BROKERS", "localhost:9092")),
+		OrderEventsTopic:   envOr("ORDER_KAFKA_ORDER_EVENTS_TOPIC", "order-service.order-events"),
+		PaymentsTopic:      envOr("ORDER_KAFKA_PAYMENTS_TOPIC", "payments.completed"),
+		ShippingTopic:      envOr("ORDER_KAFKA_SHIPPING_TOPIC", "shipping.updates"),
+		InventoryTopic:     envOr("ORDER_KAFKA_INVENTORY_TOPIC", "inventory.updates"),
+		ConsumerGroup:      envOr("ORDER_KAFKA_CONSUMER_GROUP", "order-service"),
+		OutboxPollInterval: 2 * time.Second,
+		OutboxBatchSize:    100,
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func splitCSV(v string) []string {
+	parts := strings.Split(v, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/segmentio/kafka-go"
+
+	"order-service/observability"
+)
+
+// Handler processes one consumed message's raw value.
+type Handler func(ctx context.Context, value []byte) error
+
+// Consumer reads one topic in a consumer group and hands each message to
+// handler, committing only after handler succeeds so a crash mid-handle
+// redelivers the message.
+type Consumer struct {
+	reader  *kafka.Reader
+	handler Handler
+}
+
+func NewConsumer(brokers []string, topic, groupID string, handler Handler) *Consumer {
+	return &Consumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			Topic:   topic,
+			GroupID: groupID,
+		}),
+		handler: handler,
+	}
+}
+
+// Run consumes until ctx is cancelled or the reader is closed.
+func (c *Consumer) Run(ctx context.Context) {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, kafka.ErrGroupClosed) || errors.Is(err, io.EOF) {
+				return
+			}
+			log.Printf("events: fetch message from %s: %v", c.reader.Config().Topic, err)
+			continue
+		}
+		spanCtx, span := observability.StartSpan(ctx, "kafka.consume "+c.reader.Config().Topic)
+		err = c.handler(spanCtx, msg.Value)
+		span.End()
+		if err != nil {
+			log.Printf("events: handle message from %s: %v", c.reader.Config().Topic, err)
+			continue
+		}
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			log.Printf("events: commit message from %s: %v", c.reader.Config().Topic, err)
+		}
+	}
+}
+
+func (c *Consumer) Close() error {
+	return c.reader.Close()
+}
+
+// StartWebhookConsumers runs the Kafka counterparts of the /webhooks/*
+// HTTP endpoints: payments.completed, shipping.updates, and
+// inventory.updates each get a consumer in the same group so exactly one
+// replica processes a given message.
+func StartWebhookConsumers(ctx context.Context, cfg Config, paymentCompleted, shippingUpdate, inventoryUpdate Handler) []*Consumer {
+	consumers := []*Consumer{
+		NewConsumer(cfg.Brokers, cfg.PaymentsTopic, cfg.ConsumerGroup, paymentCompleted),
+		NewConsumer(cfg.Brokers, cfg.ShippingTopic, cfg.ConsumerGroup, shippingUpdate),
+		NewConsumer(cfg.Brokers, cfg.InventoryTopic, cfg.ConsumerGroup, inventoryUpdate),
+	}
+	for _, c := range consumers {
+		go c.Run(ctx)
+	}
+	return consumers
+}
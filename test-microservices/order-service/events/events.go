@@ -0,0 +1,53 @@
+// Package events publishes order-service domain events to Kafka using
+// CloudEvents-compatible envelopes, and runs the outbox worker plus
+// webhook consumer counterparts that keep topics and /webhooks/* in
+// sync.
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types published on order lifecycle changes.
+const (
+	TypeOrderCreated       = "order.created"
+	TypeOrderStatusChanged = "order.status_changed"
+	TypeOrderCancelled     = "order.cancelled"
+	TypeOrderReturned      = "order.returned"
+	TypeCartCheckedOut     = "cart.checked_out"
+)
+
+// Source identifies this service in every envelope's "source" field.
+const Source = "order-service"
+
+// Envelope is a CloudEvents 1.0 JSON envelope.
+type Envelope struct {
+	SpecVersion string          `json:"specversion"`
+	ID          string          `json:"id"`
+	Source      string          `json:"source"`
+	Type        string          `json:"type"`
+	Subject     string          `json:"subject"`
+	Time        time.Time       `json:"time"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// NewEnvelope builds an Envelope for orderID carrying data as its
+// CloudEvents "data" field.
+func NewEnvelope(eventType, orderID string, data interface{}) (Envelope, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, err
+	}
+	return Envelope{
+		SpecVersion: "1.0",
+		ID:          uuid.New().String(),
+		Source:      Source,
+		Type:        eventType,
+		Subject:     orderID,
+		Time:        time.Now(),
+		Data:        payload,
+	}, nil
+}
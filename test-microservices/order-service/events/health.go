@@ -0,0 +1,22 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Ping dials the first configured broker to confirm Kafka is actually
+// reachable, rather than assuming it is. Callers should bound ctx with a
+// short timeout since this is typically called from a readiness probe.
+func Ping(ctx context.Context, brokers []string) error {
+	if len(brokers) == 0 {
+		return fmt.Errorf("events: no brokers configured")
+	}
+	conn, err := kafka.DialContext(ctx, "tcp", brokers[0])
+	if err != nil {
+		return fmt.Errorf("events: dial broker %s: %w", brokers[0], err)
+	}
+	return conn.Close()
+}
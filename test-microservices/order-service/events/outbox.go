@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"order-service/store"
+)
+
+// Stage writes event into the outbox within the caller's transaction
+// (s is expected to be the transaction-scoped Store passed into
+// store.Store.WithTx). The OutboxWorker publishes it afterwards, giving
+// the state change and the event an all-or-nothing commit.
+func Stage(ctx context.Context, s store.Store, topic, eventType, orderID string, data interface{}) error {
+	envelope, err := NewEnvelope(eventType, orderID, data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return s.EnqueueEvent(ctx, &store.OutboxEvent{
+		ID:        envelope.ID,
+		Topic:     topic,
+		Type:      eventType,
+		Subject:   orderID,
+		Payload:   payload,
+		CreatedAt: envelope.Time,
+	})
+}
+
+// OutboxWorker polls the outbox table and publishes undelivered events,
+// decoupling the HTTP request path from Kafka availability.
+type OutboxWorker struct {
+	store     store.Store
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+}
+
+func NewOutboxWorker(s store.Store, publisher Publisher, cfg Config) *OutboxWorker {
+	return &OutboxWorker{store: s, publisher: publisher, interval: cfg.OutboxPollInterval, batchSize: cfg.OutboxBatchSize}
+}
+
+// Run polls until ctx is cancelled, so callers can shut it down
+// gracefully alongside the rest of the service.
+func (w *OutboxWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.publishPending(ctx)
+		}
+	}
+}
+
+func (w *OutboxWorker) publishPending(ctx context.Context) {
+	pending, err := w.store.FetchUndelivered(ctx, w.batchSize)
+	if err != nil {
+		log.Printf("events: fetch undelivered outbox events: %v", err)
+		return
+	}
+	for _, evt := range pending {
+		var envelope Envelope
+		if err := json.Unmarshal(evt.Payload, &envelope); err != nil {
+			log.Printf("events: decode outbox event %s: %v", evt.ID, err)
+			continue
+		}
+		if err := w.publisher.Publish(ctx, evt.Topic, envelope); err != nil {
+			log.Printf("events: publish outbox event %s to %s: %v", evt.ID, evt.Topic, err)
+			continue
+		}
+		if err := w.store.MarkDelivered(ctx, evt.ID); err != nil {
+			log.Printf("events: mark outbox event %s delivered: %v", evt.ID, err)
+		}
+	}
+}
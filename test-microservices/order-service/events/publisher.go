@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+
+	"order-service/observability"
+)
+
+// Publisher sends a single CloudEvents envelope to topic, keyed by the
+// envelope's subject so per-order events land in the same partition.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, envelope Envelope) error
+	Close() error
+}
+
+// KafkaPublisher publishes via segmentio/kafka-go, reusing one Writer per
+// topic for the lifetime of the process.
+type KafkaPublisher struct {
+	brokers []string
+	writers map[string]*kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string) *KafkaPublisher {
+	return &KafkaPublisher{brokers: brokers, writers: map[string]*kafka.Writer{}}
+}
+
+func (p *KafkaPublisher) writerFor(topic string) *kafka.Writer {
+	if w, ok := p.writers[topic]; ok {
+		return w
+	}
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(p.brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+	p.writers[topic] = w
+	return w
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, topic string, envelope Envelope) error {
+	ctx, span := observability.StartSpan(ctx, "kafka.publish "+topic)
+	defer span.End()
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return p.writerFor(topic).WriteMessages(ctx, kafka.Message{
+		Key:   []byte(envelope.Subject),
+		Value: body,
+	})
+}
+
+func (p *KafkaPublisher) Close() error {
+	for _, w := range p.writers {
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Package idempotency implements the Idempotency-Key contract for
+// mutating cart/order/webhook routes: a retried request with the same
+// key replays the first response instead of re-applying the mutation.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TTL is how long a cached response is replayable.
+const TTL = 24 * time.Hour
+
+const headerKey = "Idempotency-Key"
+
+// lockTTL bounds how long one request may hold the lock before it's
+// considered abandoned. lockWaitTimeout/lockPollInterval bound how long
+// a concurrent request with the same key polls for the first request's
+// lock to free up or its cached response to appear, rather than failing
+// immediately.
+const (
+	lockTTL          = 10 * time.Second
+	lockWaitTimeout  = 10 * time.Second
+	lockPollInterval = 100 * time.Millisecond
+)
+
+// Record is the cached first response for a given idempotency key.
+type Record struct {
+	RequestHash string            `json:"requestHash"`
+	Status      int               `json:"status"`
+	Headers     map[string]string `json:"headers"`
+	Body        []byte            `json:"body"`
+}
+
+// Store persists idempotency records and provides the short-lived lock
+// that serializes concurrent requests sharing a key. A Redis-backed
+// implementation is expected in production; an in-memory Store is
+// provided for tests.
+type Store interface {
+	// Lock acquires a short-lived lock for key, returning false if
+	// another request already holds it.
+	Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key string) error
+
+	Get(ctx context.Context, key string) (*Record, bool, error)
+	Save(ctx context.Context, key string, record Record, ttl time.Duration) error
+}
+
+// Middleware enforces the Idempotency-Key contract on every request it
+// wraps: a first-time key caches the response; a replayed key with an
+// identical body gets the cached response back with
+// Idempotent-Replay: true; a replayed key with a different body is
+// rejected with 422; concurrent requests for the same key block on
+// Store's lock so only one executes the handler.
+func Middleware(store Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(headerKey)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scopedKey := scopeKey(c, key)
+		requestHash := hashRequest(c, body)
+		ctx := c.Request.Context()
+
+		record, err := acquireOrAwait(ctx, store, scopedKey)
+		if errors.Is(err, errLockTimeout) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		} else if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if record != nil {
+			if record.RequestHash != requestHash {
+				c.JSON(http.StatusUnprocessableEntity, gin.H{
+					"error": "Idempotency-Key was already used with a different request body",
+				})
+				c.Abort()
+				return
+			}
+			replay(c, record)
+			c.Abort()
+			return
+		}
+		defer store.Unlock(ctx, scopedKey)
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = recorder
+		c.Next()
+
+		respRecord := Record{
+			RequestHash: requestHash,
+			Status:      recorder.status,
+			Headers:     captureHeaders(recorder.Header()),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Save(ctx, scopedKey, respRecord, TTL); err != nil {
+			// The response already went out to the client; a failure to
+			// cache it only means a retry won't be deduplicated.
+			c.Error(err)
+		}
+	}
+}
+
+// errLockTimeout means another request held the key's lock for the
+// entire wait window without ever saving a cached response.
+var errLockTimeout = errors.New("idempotency: timed out waiting for the in-flight request to complete")
+
+// acquireOrAwait acquires the key's lock so the caller can run the
+// handler. If another request already holds it, acquireOrAwait polls
+// until either that request's cached response appears (returned for the
+// caller to replay) or the lock frees up and this call claims it
+// instead, so concurrent requests sharing a key block on the same
+// result rather than racing each other. It gives up with
+// errLockTimeout after lockWaitTimeout.
+func acquireOrAwait(ctx context.Context, store Store, scopedKey string) (*Record, error) {
+	deadline := time.Now().Add(lockWaitTimeout)
+	for {
+		record, found, err := store.Get(ctx, scopedKey)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return record, nil
+		}
+
+		acquired, err := store.Lock(ctx, scopedKey, lockTTL)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return nil, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errLockTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+func scopeKey(c *gin.Context, key string) string {
+	userID := c.GetString("userId")
+	return userID + ":" + c.Request.Method + ":" + c.FullPath() + ":" + key
+}
+
+func hashRequest(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.GetString("userId")))
+	h.Write([]byte(c.Request.Method))
+	h.Write([]byte(c.Request.URL.Path))
+	bodyHash := sha256.Sum256(body)
+	h.Write(bodyHash[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(c *gin.Context, record *Record) {
+	for k, v := range record.Headers {
+		c.Header(k, v)
+	}
+	c.Header("Idempotent-Replay", "true")
+	c.Data(record.Status, contentType(record.Headers), record.Body)
+}
+
+func contentType(headers map[string]string) string {
+	if ct, ok := headers["Content-Type"]; ok {
+		return ct
+	}
+	return "application/json"
+}
+
+func captureHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		out[k] = h.Get(k)
+	}
+	return out
+}
+
+// responseRecorder captures the handler's response so it can be cached
+// after the fact, while still writing through to the real client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body   *bytes.Buffer
+	status int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
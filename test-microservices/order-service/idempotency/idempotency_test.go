@@ -0,0 +1,114 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(calls *int32) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware(NewMemoryStore()))
+	r.POST("/api/v1/orders", func(c *gin.Context) {
+		n := atomic.AddInt32(calls, 1)
+		c.JSON(http.StatusCreated, gin.H{"orderId": strconv.Itoa(int(n))})
+	})
+	return r
+}
+
+func doPost(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestReplayReturnsCachedResponse(t *testing.T) {
+	var calls int32
+	r := newTestRouter(&calls)
+
+	first := doPost(r, "key-1", `{"items":[]}`)
+	second := doPost(r, "key-1", `{"items":[]}`)
+
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Fatalf("replay body %q does not match original %q", second.Body.String(), first.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replay") != "true" {
+		t.Fatalf("expected Idempotent-Replay header on replay")
+	}
+}
+
+func TestDifferentBodySameKeyRejected(t *testing.T) {
+	var calls int32
+	r := newTestRouter(&calls)
+
+	doPost(r, "key-2", `{"items":[]}`)
+	resp := doPost(r, "key-2", `{"items":["different"]}`)
+
+	if resp.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for reused key with different body, got %d", resp.Code)
+	}
+}
+
+// TestConcurrentRequestsShareLockAndResult proves the acquireOrAwait
+// blocking/polling path: a second request sharing a key with an
+// in-flight first request must wait for that first request's result
+// rather than running the handler itself or erroring out.
+func TestConcurrentRequestsShareLockAndResult(t *testing.T) {
+	var calls int32
+	started := make(chan struct{})
+	proceed := make(chan struct{})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware(NewMemoryStore()))
+	r.POST("/api/v1/orders", func(c *gin.Context) {
+		n := atomic.AddInt32(&calls, 1)
+		close(started)
+		<-proceed
+		c.JSON(http.StatusCreated, gin.H{"orderId": strconv.Itoa(int(n))})
+	})
+
+	results := make(chan *httptest.ResponseRecorder, 2)
+	go func() { results <- doPost(r, "concurrent-key", `{"items":[]}`) }()
+
+	<-started // the first request now holds the lock, blocked inside the handler
+
+	go func() { results <- doPost(r, "concurrent-key", `{"items":[]}`) }()
+	time.Sleep(50 * time.Millisecond) // let the second request start polling the lock
+	close(proceed)
+
+	first, second := <-results, <-results
+
+	if calls != 1 {
+		t.Fatalf("expected handler to run exactly once for concurrent requests sharing a key, ran %d times", calls)
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Fatalf("responses diverged: %q vs %q", first.Body.String(), second.Body.String())
+	}
+}
+
+func TestMissingKeyBypassesCaching(t *testing.T) {
+	var calls int32
+	r := newTestRouter(&calls)
+
+	doPost(r, "", `{"items":[]}`)
+	doPost(r, "", `{"items":[]}`)
+
+	if calls != 2 {
+		t.Fatalf("expected handler to run for every request without a key, ran %d times", calls)
+	}
+}
@@ -0,0 +1,55 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store used in tests in place of Redis.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+	locks   map[string]struct{}
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: map[string]Record{},
+		locks:   map[string]struct{}{},
+	}
+}
+
+func (s *MemoryStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, held := s.locks[key]; held {
+		return false, nil
+	}
+	s.locks[key] = struct{}{}
+	return true, nil
+}
+
+func (s *MemoryStore) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.locks, key)
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return &record, true, nil
+}
+
+func (s *MemoryStore) Save(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = record
+	return nil
+}
@@ -0,0 +1,54 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is the production Store backend: records live at
+// "idempotency:record:<key>" and locks at "idempotency:lock:<key>",
+// both expiring on their own via Redis TTLs.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) recordKey(key string) string { return "idempotency:record:" + key }
+func (s *RedisStore) lockKey(key string) string    { return "idempotency:lock:" + key }
+
+func (s *RedisStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.lockKey(key), "1", ttl).Result()
+}
+
+func (s *RedisStore) Unlock(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.lockKey(key)).Err()
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) (*Record, bool, error) {
+	raw, err := s.client.Get(ctx, s.recordKey(key)).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var record Record
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, false, err
+	}
+	return &record, true, nil
+}
+
+func (s *RedisStore) Save(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.recordKey(key), raw, ttl).Err()
+}
@@ -3,105 +3,237 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	ginjwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"order-service/auth"
+	"order-service/dto"
+	"order-service/events"
+	"order-service/idempotency"
+	"order-service/observability"
+	"order-service/openapi"
+	"order-service/problem"
+	"order-service/shipping"
+	"order-service/shipping/carriers"
+	"order-service/store"
 )
 
 // =============================================================================
 // MODELS
 // =============================================================================
 
-type OrderStatus string
+// Order/Cart models live in the store package now that the service has a
+// real persistence layer; aliased here so handler code doesn't change.
+type OrderStatus = store.OrderStatus
 
 const (
-	StatusPending    OrderStatus = "pending"
-	StatusConfirmed  OrderStatus = "confirmed"
-	StatusProcessing OrderStatus = "processing"
-	StatusShipped    OrderStatus = "shipped"
-	StatusDelivered  OrderStatus = "delivered"
-	StatusCancelled  OrderStatus = "cancelled"
+	StatusPending    = store.StatusPending
+	StatusConfirmed  = store.StatusConfirmed
+	StatusProcessing = store.StatusProcessing
+	StatusShipped    = store.StatusShipped
+	StatusDelivered  = store.StatusDelivered
+	StatusCancelled  = store.StatusCancelled
 )
 
-type OrderItem struct {
-	ProductID string  `json:"productId"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
-}
-
-type Order struct {
-	ID          string      `json:"id"`
-	CustomerID  string      `json:"customerId"`
-	Items       []OrderItem `json:"items"`
-	Total       float64     `json:"total"`
-	Status      OrderStatus `json:"status"`
-	ShippingAddr string     `json:"shippingAddress"`
-	CreatedAt   time.Time   `json:"createdAt"`
-	UpdatedAt   time.Time   `json:"updatedAt"`
-}
-
-type CartItem struct {
-	ProductID string `json:"productId"`
-	Quantity  int    `json:"quantity"`
-}
-
-type Cart struct {
-	ID         string     `json:"id"`
-	CustomerID string     `json:"customerId"`
-	Items      []CartItem `json:"items"`
-	CreatedAt  time.Time  `json:"createdAt"`
-}
+type OrderItem = store.OrderItem
+type Order = store.Order
+type CartItem = store.CartItem
+type Cart = store.Cart
 
 // =============================================================================
 // MIDDLEWARE
 // =============================================================================
 
+// AuthMiddleware verifies the request's JWT via jwtMiddleware and copies
+// the identity it extracts into the "userId"/"roles" context keys the
+// handlers below already expect.
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := c.GetHeader("Authorization")
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "No authorization token"})
-			c.Abort()
+		jwtMiddleware.MiddlewareFunc()(c)
+		if c.IsAborted() {
 			return
 		}
-		// Validate token (mock)
-		c.Set("userId", "user_123")
+		identity, _ := c.Get(auth.IdentityKey)
+		if id, ok := identity.(auth.Identity); ok {
+			c.Set("userId", id.UserID)
+			c.Set("roles", id.Roles)
+		}
 		c.Next()
 	}
 }
 
+// AdminMiddleware requires a verified JWT carrying the "admin" role.
 func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		adminKey := c.GetHeader("X-Admin-Key")
-		if adminKey == "" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
-			c.Abort()
+		AuthMiddleware()(c)
+		if c.IsAborted() {
 			return
 		}
-		c.Next()
+		auth.RequireRole("admin")(c)
+	}
+}
+
+// adminUsernames holds the operator-configured set of usernames that log
+// in with the admin role; everyone else only ever gets "customer", no
+// matter what they submit. Populated from ORDER_ADMIN_USERNAMES so the
+// admin role can't be self-granted from login-request input.
+var adminUsernames map[string]bool
+
+// authenticateCustomer stands in for the service's real identity
+// provider: any non-empty username/password pair logs in. Roles are not
+// derived from anything the caller submits; admin is granted only to
+// usernames the operator listed in adminUsernames. Swap this for a call
+// to the actual user service before production use.
+func authenticateCustomer(c *gin.Context, username, password string) (auth.Identity, error) {
+	if username == "" || password == "" {
+		return auth.Identity{}, ginjwt.ErrFailedAuthentication
+	}
+	roles := []string{"customer"}
+	if adminUsernames[username] {
+		roles = append(roles, "admin")
+	}
+	return auth.Identity{UserID: username, Roles: roles}, nil
+}
+
+// logout revokes the caller's current access token by jti, so it's
+// rejected on any subsequent request even though it hasn't expired yet.
+func logout(c *gin.Context) {
+	if err := auth.Revoke(c, tokenBlocklist); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
+	return fallback
 }
 
 // =============================================================================
 // MAIN
 // =============================================================================
 
+// db, jwtMiddleware, and eventsPublisher are the process-wide store/auth/
+// event handles. Handlers are plain gin.HandlerFunc values (not methods
+// on a struct) throughout this file, so we keep the same shape and reach
+// them through these package-level vars rather than threading them
+// through every handler signature.
+var (
+	db               store.Store
+	jwtMiddleware    *ginjwt.GinJWTMiddleware
+	tokenBlocklist   auth.Blocklist
+	eventsCfg        events.Config
+	eventsPublisher  events.Publisher
+	idempotencyStore idempotency.Store
+	carrierRegistry  *carriers.Registry
+)
+
 func main() {
-	r := gin.Default()
+	cfg, err := store.LoadConfig(os.Getenv("ORDER_SERVICE_CONFIG"))
+	if err != nil {
+		panic(err)
+	}
+	db, err = store.Open(cfg)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	redisClient := redis.NewClient(&redis.Options{Addr: envOr("ORDER_REDIS_ADDR", "localhost:6379")})
+	idempotencyStore = idempotency.NewRedisStore(redisClient)
+
+	adminUsernames = map[string]bool{}
+	for _, name := range strings.Split(os.Getenv("ORDER_ADMIN_USERNAMES"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			adminUsernames[name] = true
+		}
+	}
+
+	tokenBlocklist = auth.NewRedisBlocklist(redisClient)
+	jwtMiddleware, err = auth.New(auth.Config{
+		Realm:            "order-service",
+		SigningAlgorithm: envOr("ORDER_JWT_ALGORITHM", "HS256"),
+		Secret:           []byte(envOr("ORDER_JWT_SECRET", "dev-secret-change-me")),
+		PubKeyFile:       os.Getenv("ORDER_JWT_PUBKEY_FILE"),
+		JWKSURL:          os.Getenv("ORDER_JWT_JWKS_URL"),
+		Blocklist:        tokenBlocklist,
+		Authenticate:     authenticateCustomer,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := observability.InitTracer(ctx)
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing(context.Background())
+
+	logger, err := observability.NewLogger()
+	if err != nil {
+		panic(err)
+	}
+	defer logger.Sync()
+
+	eventsCfg = events.LoadConfig()
+	eventsPublisher = events.NewKafkaPublisher(eventsCfg.Brokers)
+	defer eventsPublisher.Close()
+
+	outboxWorker := events.NewOutboxWorker(db, eventsPublisher, eventsCfg)
+	go outboxWorker.Run(ctx)
 
-	// Health endpoints
+	carrierRegistry = shipping.NewRegistry(shipping.LoadConfig())
+
+	consumers := events.StartWebhookConsumers(ctx, eventsCfg,
+		kafkaPaymentCompletedHandler, kafkaShippingUpdateHandler, kafkaInventoryUpdateHandler)
+	defer func() {
+		for _, c := range consumers {
+			c.Close()
+		}
+	}()
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(observability.TracingMiddleware())
+	r.Use(observability.MetricsMiddleware())
+	r.Use(observability.LoggingMiddleware(logger))
+
+	r.POST("/api/v1/auth/login", jwtMiddleware.LoginHandler)
+	r.POST("/api/v1/auth/refresh", jwtMiddleware.RefreshHandler)
+	r.POST("/api/v1/auth/logout", AuthMiddleware(), logout)
+
+	// Health and metrics endpoints
 	r.GET("/health", healthCheck)
 	r.GET("/health/live", livenessProbe)
 	r.GET("/health/ready", readinessProbe)
+	r.GET("/metrics", gin.WrapH(observability.MetricsHandler()))
+	r.GET("/openapi.json", serveOpenAPISpec)
+	r.GET("/docs", serveDocs)
 
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
 		// Cart endpoints (authenticated)
 		cart := v1.Group("/cart")
-		cart.Use(AuthMiddleware())
+		cart.Use(AuthMiddleware(), idempotency.Middleware(idempotencyStore))
 		{
 			cart.GET("", getCart)
 			cart.POST("/items", addToCart)
@@ -113,7 +245,7 @@ func main() {
 
 		// Order endpoints (authenticated)
 		orders := v1.Group("/orders")
-		orders.Use(AuthMiddleware())
+		orders.Use(AuthMiddleware(), idempotency.Middleware(idempotencyStore))
 		{
 			orders.POST("", createOrder)
 			orders.GET("", listOrders)
@@ -133,6 +265,7 @@ func main() {
 			shipping.GET("/rates", getShippingRates)
 			shipping.POST("/calculate", calculateShipping)
 			shipping.GET("/methods", listShippingMethods)
+			shipping.POST("/labels", createShippingLabel)
 		}
 	}
 
@@ -151,11 +284,25 @@ func main() {
 	}
 
 	// Webhooks
-	r.POST("/webhooks/payment-completed", paymentCompletedWebhook)
-	r.POST("/webhooks/shipping-update", shippingUpdateWebhook)
-	r.POST("/webhooks/inventory-update", inventoryUpdateWebhook)
+	webhooks := r.Group("/webhooks")
+	webhooks.Use(idempotency.Middleware(idempotencyStore))
+	{
+		webhooks.POST("/payment-completed", paymentCompletedWebhook)
+		webhooks.POST("/shipping-update", shippingUpdateWebhook)
+		webhooks.POST("/inventory-update", inventoryUpdateWebhook)
+	}
 
-	r.Run(":8003")
+	srv := &http.Server{Addr: ":8003", Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			panic(err)
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	srv.Shutdown(shutdownCtx)
 }
 
 // =============================================================================
@@ -174,123 +321,423 @@ func livenessProbe(c *gin.Context) {
 }
 
 func readinessProbe(c *gin.Context) {
+	dbStatus := "connected"
+	if err := db.Ping(c.Request.Context()); err != nil {
+		dbStatus = "disconnected"
+	}
+
+	kafkaStatus := "connected"
+	kafkaCtx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+	defer cancel()
+	if err := events.Ping(kafkaCtx, eventsCfg.Brokers); err != nil {
+		kafkaStatus = "disconnected"
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"ready":    true,
-		"database": "connected",
-		"kafka":    "connected",
+		"ready":    dbStatus == "connected" && kafkaStatus == "connected",
+		"database": dbStatus,
+		"kafka":    kafkaStatus,
 	})
 }
 
+// =============================================================================
+// API DOCS
+// =============================================================================
+
+func serveOpenAPISpec(c *gin.Context) {
+	spec, err := openapi.Spec()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, spec)
+}
+
+// docsHTML loads Swagger UI from a CDN against /openapi.json rather than
+// vendoring the asset bundle.
+const docsHTML = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Order Service API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>`
+
+func serveDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsHTML))
+}
+
 // =============================================================================
 // CART HANDLERS
 // =============================================================================
 
 func getCart(c *gin.Context) {
 	userID := c.GetString("userId")
-	c.JSON(http.StatusOK, Cart{
-		ID:         uuid.New().String(),
-		CustomerID: userID,
-		Items:      []CartItem{},
-		CreatedAt:  time.Now(),
-	})
+	cart, err := db.GetCart(c.Request.Context(), userID)
+	if err == store.ErrNotFound {
+		cart = &Cart{ID: uuid.New().String(), CustomerID: userID, Items: []CartItem{}, CreatedAt: time.Now()}
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cart)
 }
 
 func addToCart(c *gin.Context) {
-	var item CartItem
-	if err := c.ShouldBindJSON(&item); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req dto.AddToCartRequest
+	if !problem.BindJSON(c, &req) {
+		return
+	}
+	item := CartItem{ProductID: req.ProductID, Quantity: req.Quantity}
+
+	ctx := c.Request.Context()
+	userID := c.GetString("userId")
+	cart, err := db.GetCart(ctx, userID)
+	if err == store.ErrNotFound {
+		cart = &Cart{ID: uuid.New().String(), CustomerID: userID, CreatedAt: time.Now()}
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	cart.Items = append(cart.Items, item)
+	if err := db.SaveCart(ctx, cart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Item added to cart", "item": item})
 }
 
 func updateCartItem(c *gin.Context) {
+	var req dto.UpdateCartItemRequest
+	if !problem.BindJSON(c, &req) {
+		return
+	}
+
 	itemID := c.Param("itemId")
+	ctx := c.Request.Context()
+	userID := c.GetString("userId")
+	cart, err := db.GetCart(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cart not found"})
+		return
+	}
+	for i := range cart.Items {
+		if cart.Items[i].ProductID == itemID {
+			cart.Items[i].Quantity = req.Quantity
+			break
+		}
+	}
+	if err := db.SaveCart(ctx, cart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Cart item updated", "itemId": itemID})
 }
 
 func removeFromCart(c *gin.Context) {
 	itemID := c.Param("itemId")
+	ctx := c.Request.Context()
+	userID := c.GetString("userId")
+	cart, err := db.GetCart(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cart not found"})
+		return
+	}
+	kept := cart.Items[:0]
+	for _, item := range cart.Items {
+		if item.ProductID != itemID {
+			kept = append(kept, item)
+		}
+	}
+	cart.Items = kept
+	if err := db.SaveCart(ctx, cart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Item removed from cart", "itemId": itemID})
 }
 
 func clearCart(c *gin.Context) {
+	userID := c.GetString("userId")
+	if err := db.ClearCart(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Cart cleared"})
 }
 
 func checkout(c *gin.Context) {
-	orderID := uuid.New().String()
+	ctx := c.Request.Context()
+	userID := c.GetString("userId")
+	cart, err := db.GetCart(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cart is empty"})
+		return
+	}
+
+	order := &Order{
+		ID:         uuid.New().String(),
+		CustomerID: userID,
+		Items:      cartItemsToOrderItems(cart.Items),
+		Status:     StatusConfirmed,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	err = db.WithTx(ctx, func(tx store.Store) error {
+		if err := tx.CreateOrder(ctx, order); err != nil {
+			return err
+		}
+		if err := tx.ClearCart(ctx, userID); err != nil {
+			return err
+		}
+		return events.Stage(ctx, tx, eventsCfg.OrderEventsTopic, events.TypeCartCheckedOut, order.ID, order)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	observability.RecordCartCheckout()
 	c.JSON(http.StatusCreated, gin.H{
-		"orderId": orderID,
-		"status":  "confirmed",
+		"orderId": order.ID,
+		"status":  order.Status,
 	})
 }
 
+// cartItemsToOrderItems snapshots a cart into the line items an order
+// persists. CartItem carries no price, so every item's Price is zero and
+// checkout saves the order with a zero Total.
+func cartItemsToOrderItems(items []CartItem) []OrderItem {
+	out := make([]OrderItem, len(items))
+	for i, item := range items {
+		out[i] = OrderItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	return out
+}
+
 // =============================================================================
 // ORDER HANDLERS
 // =============================================================================
 
 func createOrder(c *gin.Context) {
-	orderID := uuid.New().String()
-	c.JSON(http.StatusCreated, Order{
-		ID:        orderID,
-		Status:    StatusPending,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	var req dto.CreateOrderRequest
+	if !problem.BindJSON(c, &req) {
+		return
+	}
+
+	items := make([]OrderItem, len(req.Items))
+	var total float64
+	for i, it := range req.Items {
+		items[i] = OrderItem{ProductID: it.ProductID, Quantity: it.Quantity, Price: it.Price}
+		total += it.Price * float64(it.Quantity)
+	}
+
+	order := Order{
+		ID:              uuid.New().String(),
+		CustomerID:      c.GetString("userId"),
+		Items:           items,
+		Total:           total,
+		Status:          StatusPending,
+		ShippingAddress: req.ShippingAddress,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	ctx := c.Request.Context()
+	err := db.WithTx(ctx, func(tx store.Store) error {
+		if err := tx.CreateOrder(ctx, &order); err != nil {
+			return err
+		}
+		return events.Stage(ctx, tx, eventsCfg.OrderEventsTopic, events.TypeOrderCreated, order.ID, order)
 	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	observability.RecordOrderCreated()
+	c.JSON(http.StatusCreated, order)
 }
 
 func listOrders(c *gin.Context) {
+	userID := c.GetString("userId")
+	offset, limit := paginationParams(c)
+
+	page, err := db.ListOrders(c.Request.Context(), userID, offset, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"orders": []Order{},
-		"total":  0,
+		"orders": page.Orders,
+		"total":  page.Total,
 	})
 }
 
+// loadOwnedOrder fetches orderID and verifies it belongs to the
+// authenticated caller before a handler reads or mutates it. A customer
+// must not be able to tell "doesn't exist" apart from "belongs to
+// someone else", so both cases write a 404 and return ok=false.
+func loadOwnedOrder(c *gin.Context, orderID string) (*store.Order, bool) {
+	order, err := db.GetOrder(c.Request.Context(), orderID)
+	if err == store.ErrNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return nil, false
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if order.CustomerID != c.GetString("userId") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return nil, false
+	}
+	return order, true
+}
+
 func getOrder(c *gin.Context) {
-	orderID := c.Param("orderId")
-	c.JSON(http.StatusOK, Order{
-		ID:        orderID,
-		Status:    StatusConfirmed,
-		CreatedAt: time.Now(),
-	})
+	order, ok := loadOwnedOrder(c, c.Param("orderId"))
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, order)
 }
 
 func updateOrder(c *gin.Context) {
 	orderID := c.Param("orderId")
+	var req dto.UpdateOrderStatusRequest
+	if !problem.BindJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	current, ok := loadOwnedOrder(c, orderID)
+	if !ok {
+		return
+	}
+	if !dto.CanTransition(current.Status, req.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "invalid status transition", "from": current.Status, "to": req.Status})
+		return
+	}
+
+	err := db.WithTx(ctx, func(tx store.Store) error {
+		if err := tx.UpdateOrderStatus(ctx, orderID, req.Status); err != nil {
+			return err
+		}
+		return events.Stage(ctx, tx, eventsCfg.OrderEventsTopic, events.TypeOrderStatusChanged, orderID, req)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Order updated", "orderId": orderID})
 }
 
 func cancelOrder(c *gin.Context) {
 	orderID := c.Param("orderId")
+	if _, ok := loadOwnedOrder(c, orderID); !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	err := db.WithTx(ctx, func(tx store.Store) error {
+		if err := tx.CancelOrder(ctx, orderID); err != nil {
+			return err
+		}
+		return events.Stage(ctx, tx, eventsCfg.OrderEventsTopic, events.TypeOrderCancelled, orderID, gin.H{"orderId": orderID})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	observability.RecordOrderCancelled()
 	c.JSON(http.StatusOK, gin.H{"message": "Order cancelled", "orderId": orderID})
 }
 
 func getOrderStatus(c *gin.Context) {
 	orderID := c.Param("orderId")
+	order, ok := loadOwnedOrder(c, orderID)
+	if !ok {
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"orderId": orderID,
-		"status":  "processing",
+		"status":  order.Status,
 	})
 }
 
+// paginationParams reads offset/limit query params with sane defaults;
+// ListOrders callers should never pass raw, unvalidated query values.
+func paginationParams(c *gin.Context) (offset, limit int) {
+	offset, _ = strconv.Atoi(c.Query("offset"))
+	limit, _ = strconv.Atoi(c.Query("limit"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset, limit
+}
+
 func getOrderTracking(c *gin.Context) {
 	orderID := c.Param("orderId")
+	order, ok := loadOwnedOrder(c, orderID)
+	if !ok {
+		return
+	}
+	if order.TrackingNumber == "" {
+		c.JSON(http.StatusOK, gin.H{
+			"orderId": orderID,
+			"status":  "not_shipped",
+			"events":  []gin.H{},
+		})
+		return
+	}
+
+	info, err := carrierRegistry.Track(c.Request.Context(), order.TrackingNumber)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"orderId":        orderID,
-		"trackingNumber": "1Z999AA10123456784",
-		"carrier":        "UPS",
-		"events":         []gin.H{},
+		"trackingNumber": info.TrackingNumber,
+		"carrier":        info.Carrier,
+		"status":         info.Status,
+		"events":         info.Events,
 	})
 }
 
 func initiateReturn(c *gin.Context) {
 	orderID := c.Param("orderId")
-	returnID := uuid.New().String()
+	if _, ok := loadOwnedOrder(c, orderID); !ok {
+		return
+	}
+	ret := &store.Return{
+		ID:        uuid.New().String(),
+		OrderID:   orderID,
+		Status:    "initiated",
+		CreatedAt: time.Now(),
+	}
+	ctx := c.Request.Context()
+	err := db.WithTx(ctx, func(tx store.Store) error {
+		if err := tx.CreateReturn(ctx, ret); err != nil {
+			return err
+		}
+		return events.Stage(ctx, tx, eventsCfg.OrderEventsTopic, events.TypeOrderReturned, orderID, ret)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusCreated, gin.H{
-		"returnId": returnID,
+		"returnId": ret.ID,
 		"orderId":  orderID,
-		"status":   "initiated",
+		"status":   ret.Status,
 	})
 }
 
@@ -299,20 +746,39 @@ func initiateReturn(c *gin.Context) {
 // =============================================================================
 
 func getShippingRates(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"rates": []gin.H{
-			{"method": "standard", "price": 5.99, "days": "5-7"},
-			{"method": "express", "price": 12.99, "days": "2-3"},
-			{"method": "overnight", "price": 24.99, "days": "1"},
-		},
-	})
+	var req carriers.RateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	quotes := carrierRegistry.ShopRates(c.Request.Context(), req)
+	c.JSON(http.StatusOK, gin.H{"rates": quotes})
 }
 
 func calculateShipping(c *gin.Context) {
+	var req dto.CalculateShippingRequest
+	if !problem.BindJSON(c, &req) {
+		return
+	}
+
+	rateReq := carriers.RateRequest{
+		From:   carriers.Address(req.From),
+		To:     carriers.Address(req.To),
+		Parcel: carriers.Parcel(req.Parcel),
+	}
+	quotes := carrierRegistry.ShopRates(c.Request.Context(), rateReq)
+	if len(quotes) == 0 {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "no carrier returned a rate"})
+		return
+	}
+	shippingCost := quotes[0].Price
+	tax := req.Subtotal * req.TaxRate
 	c.JSON(http.StatusOK, gin.H{
-		"shipping":    5.99,
-		"tax":         2.50,
-		"total":       58.49,
+		"carrier":  quotes[0].Carrier,
+		"method":   quotes[0].Method,
+		"shipping": shippingCost,
+		"tax":      tax,
+		"total":    req.Subtotal + shippingCost + tax,
 	})
 }
 
@@ -322,31 +788,122 @@ func listShippingMethods(c *gin.Context) {
 	})
 }
 
+func createShippingLabel(c *gin.Context) {
+	var req carriers.LabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	carrier, err := carrierRegistry.ForMethod(req.Method)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := carrier.CreateLabel(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	orderID := c.Query("orderId")
+	if orderID != "" {
+		if err := db.SetTrackingNumber(c.Request.Context(), orderID, label.TrackingNumber); err != nil && err != store.ErrNotFound {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	contentType := "application/pdf"
+	if label.Format == "PNG" {
+		contentType = "image/png"
+	}
+	c.Header("X-Carrier", label.Carrier)
+	c.Header("X-Tracking-Number", label.TrackingNumber)
+	c.Data(http.StatusCreated, contentType, label.Content)
+}
+
 // =============================================================================
 // ADMIN HANDLERS
 // =============================================================================
 
 func adminListAllOrders(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"orders": []Order{}, "total": 0})
+	offset, limit := paginationParams(c)
+	page, err := db.ListOrders(c.Request.Context(), c.Query("customerId"), offset, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"orders": page.Orders, "total": page.Total})
 }
 
 func adminGetOrder(c *gin.Context) {
 	orderID := c.Param("orderId")
-	c.JSON(http.StatusOK, gin.H{"orderId": orderID, "adminView": true})
+	order, err := db.GetOrder(c.Request.Context(), orderID)
+	if err == store.ErrNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"orderId": orderID, "adminView": true, "order": order})
 }
 
 func adminUpdateOrderStatus(c *gin.Context) {
 	orderID := c.Param("orderId")
+	var req dto.UpdateOrderStatusRequest
+	if !problem.BindJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	current, err := db.GetOrder(ctx, orderID)
+	if err == store.ErrNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !dto.CanTransition(current.Status, req.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "invalid status transition", "from": current.Status, "to": req.Status})
+		return
+	}
+
+	err = db.WithTx(ctx, func(tx store.Store) error {
+		if err := tx.UpdateOrderStatus(ctx, orderID, req.Status); err != nil {
+			return err
+		}
+		return events.Stage(ctx, tx, eventsCfg.OrderEventsTopic, events.TypeOrderStatusChanged, orderID, req)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Order status updated", "orderId": orderID})
 }
 
 func adminDeleteOrder(c *gin.Context) {
 	orderID := c.Param("orderId")
+	if err := db.CancelOrder(c.Request.Context(), orderID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Order deleted", "orderId": orderID})
 }
 
 func adminBulkCancelOrders(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"cancelled": 0})
+	var req dto.BulkCancelOrdersRequest
+	if !problem.BindJSON(c, &req) {
+		return
+	}
+	cancelled, err := db.BulkCancelOrders(c.Request.Context(), req.OrderIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"cancelled": cancelled})
 }
 
 func adminResetDatabase(c *gin.Context) {
@@ -366,13 +923,64 @@ func adminRevenueReport(c *gin.Context) {
 // =============================================================================
 
 func paymentCompletedWebhook(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"received": true})
+	handleWebhook(c, "payment")
 }
 
 func shippingUpdateWebhook(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"received": true})
+	handleWebhook(c, "shipping")
 }
 
 func inventoryUpdateWebhook(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"received": true})
+	handleWebhook(c, "inventory")
+}
+
+// handleWebhook de-duplicates retried callbacks by event ID before
+// acknowledging them; the event ID is provided by the upstream system
+// via the X-Event-Id header.
+func handleWebhook(c *gin.Context, source string) {
+	eventID := c.GetHeader("X-Event-Id")
+	if eventID == "" {
+		eventID = uuid.New().String()
+	}
+	alreadyProcessed, err := db.MarkProcessed(c.Request.Context(), eventID, source)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	observability.RecordWebhookEvent(source)
+	c.JSON(http.StatusOK, gin.H{"received": true, "duplicate": alreadyProcessed})
+}
+
+// kafkaPaymentCompletedHandler, kafkaShippingUpdateHandler, and
+// kafkaInventoryUpdateHandler are the asynchronous counterparts of the
+// /webhooks/* HTTP endpoints above, consumed from the payments.completed,
+// shipping.updates, and inventory.updates topics respectively. Both paths
+// share the same MarkProcessed dedup so a callback delivered over both
+// HTTP and Kafka is only applied once.
+func kafkaPaymentCompletedHandler(ctx context.Context, value []byte) error {
+	return handleKafkaEvent(ctx, "payment", value)
+}
+
+func kafkaShippingUpdateHandler(ctx context.Context, value []byte) error {
+	return handleKafkaEvent(ctx, "shipping", value)
+}
+
+func kafkaInventoryUpdateHandler(ctx context.Context, value []byte) error {
+	return handleKafkaEvent(ctx, "inventory", value)
+}
+
+func handleKafkaEvent(ctx context.Context, source string, value []byte) error {
+	var envelope events.Envelope
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return err
+	}
+	eventID := envelope.ID
+	if eventID == "" {
+		eventID = uuid.New().String()
+	}
+	if _, err := db.MarkProcessed(ctx, eventID, source); err != nil {
+		return err
+	}
+	observability.RecordWebhookEvent(source)
+	return nil
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"order-service/store"
+)
+
+// testRouterAs wires just the customer-facing order routes behind a
+// fake auth middleware that sets "userId" directly, so these tests
+// exercise ownership checks without standing up real JWTs.
+func testRouterAs(userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userId", userID)
+		c.Next()
+	})
+	orders := r.Group("/api/v1/orders")
+	{
+		orders.GET("/:orderId", getOrder)
+		orders.PUT("/:orderId", updateOrder)
+		orders.DELETE("/:orderId", cancelOrder)
+		orders.GET("/:orderId/status", getOrderStatus)
+		orders.POST("/:orderId/return", initiateReturn)
+	}
+	return r
+}
+
+func setupTestDB(t *testing.T) {
+	t.Helper()
+	s, err := store.Open(store.Config{Db: store.DbConfig{
+		Driver: store.DriverSQLite,
+		Master: store.DbNode{DSN: "file::memory:?cache=shared", MaxConns: 1, IdleConns: 1},
+	}})
+	if err != nil {
+		t.Fatalf("store.Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	db = s
+}
+
+func TestCustomerCannotReadAnotherCustomersOrder(t *testing.T) {
+	setupTestDB(t)
+
+	order := &store.Order{ID: "order-1", CustomerID: "alice", Status: store.StatusPending}
+	if err := db.CreateOrder(context.Background(), order); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	r := testRouterAs("bob")
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/order-1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for another customer's order, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The owner can still read it.
+	r2 := testRouterAs("alice")
+	req2 := httptest.NewRequest(http.MethodGet, "/api/v1/orders/order-1", nil)
+	w2 := httptest.NewRecorder()
+	r2.ServeHTTP(w2, req2)
+	if w2.Code != http.StatusOK {
+		t.Fatalf("expected 200 for the owning customer, got %d: %s", w2.Code, w2.Body.String())
+	}
+}
+
+func TestCustomerCannotCancelAnotherCustomersOrder(t *testing.T) {
+	setupTestDB(t)
+
+	order := &store.Order{ID: "order-2", CustomerID: "alice", Status: store.StatusPending}
+	if err := db.CreateOrder(context.Background(), order); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	r := testRouterAs("bob")
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/order-2", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	got, err := db.GetOrder(context.Background(), "order-2")
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if got.Status != store.StatusPending {
+		t.Fatalf("expected order untouched by non-owner's cancel, got status %q", got.Status)
+	}
+}
+
+func TestCustomerCannotReturnAnotherCustomersOrder(t *testing.T) {
+	setupTestDB(t)
+
+	order := &store.Order{ID: "order-3", CustomerID: "alice", Status: store.StatusDelivered}
+	if err := db.CreateOrder(context.Background(), order); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	r := testRouterAs("bob")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/order-3/return", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
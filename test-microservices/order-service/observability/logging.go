@@ -0,0 +1,61 @@
+package observability
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLogger builds the process-wide structured JSON logger. Output goes
+// to stdout so it's picked up the same way gin's default logger was,
+// just as JSON instead of gin's plain-text access log line.
+func NewLogger() (*zap.Logger, error) {
+	cfg := zap.NewProductionConfig()
+	cfg.OutputPaths = []string{"stdout"}
+	cfg.EncoderConfig.TimeKey = "timestamp"
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	return cfg.Build()
+}
+
+// LoggingMiddleware replaces gin's default access logger with one JSON
+// line per request carrying the trace/span IDs from TracingMiddleware's
+// span, the authenticated user (if any), and a per-request ID so a
+// single request's logs can be grepped out of an aggregator.
+func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		c.Writer.Header().Set("X-Request-ID", requestID)
+
+		start := time.Now()
+		c.Next()
+
+		span := trace.SpanContextFromContext(c.Request.Context())
+		fields := []zap.Field{
+			zap.String("request_id", requestID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.FullPath()),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("user_id", c.GetString("userId")),
+		}
+		if span.HasTraceID() {
+			fields = append(fields, zap.String("trace_id", span.TraceID().String()))
+		}
+		if span.HasSpanID() {
+			fields = append(fields, zap.String("span_id", span.SpanID().String()))
+		}
+
+		if len(c.Errors) > 0 {
+			logger.Error("request completed with errors", append(fields, zap.String("errors", c.Errors.String()))...)
+			return
+		}
+		logger.Info("request completed", fields...)
+	}
+}
@@ -0,0 +1,83 @@
+// Package observability holds the cross-cutting Prometheus metrics,
+// OpenTelemetry tracing, and structured logging the service registers in
+// main() before any routes are set up.
+package observability
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests handled, labeled by method, route, and status.",
+	}, []string{"method", "path", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+
+	ordersCreatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orders_created_total",
+		Help: "Total orders created.",
+	})
+
+	ordersCancelledTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orders_cancelled_total",
+		Help: "Total orders cancelled.",
+	})
+
+	cartCheckoutTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "cart_checkout_total",
+		Help: "Total carts checked out into an order.",
+	})
+
+	webhookEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "webhook_events_total",
+		Help: "Total webhook events received, labeled by source.",
+	}, []string{"source"})
+)
+
+// MetricsHandler exposes the registered collectors for Prometheus to scrape.
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// MetricsMiddleware records request counts and latency for every route.
+// c.FullPath() is used instead of the raw URL so path params (e.g.
+// /orders/:orderId) don't blow up cardinality per unique ID.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		path := c.FullPath()
+		if path == "" {
+			path = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		httpRequestsTotal.WithLabelValues(c.Request.Method, path, status).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, path).Observe(time.Since(start).Seconds())
+	}
+}
+
+// RecordOrderCreated increments the orders_created_total counter.
+func RecordOrderCreated() { ordersCreatedTotal.Inc() }
+
+// RecordOrderCancelled increments the orders_cancelled_total counter.
+func RecordOrderCancelled() { ordersCancelledTotal.Inc() }
+
+// RecordCartCheckout increments the cart_checkout_total counter.
+func RecordCartCheckout() { cartCheckoutTotal.Inc() }
+
+// RecordWebhookEvent increments webhook_events_total for the given source.
+func RecordWebhookEvent(source string) { webhookEventsTotal.WithLabelValues(source).Inc() }
@@ -0,0 +1,87 @@
+package observability
+
+import (
+	"context"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies this service's spans in whatever backend OTLP is
+// exporting to.
+const tracerName = "order-service"
+
+// InitTracer wires up an OTLP/HTTP exporter (configured entirely via the
+// standard OTEL_EXPORTER_OTLP_* env vars) as the global TracerProvider,
+// and installs the W3C traceparent propagator. If
+// OTEL_EXPORTER_OTLP_ENDPOINT isn't set, no exporter is registered:
+// spans are still created but dropped, rather than failing startup.
+func InitTracer(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(tracerName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []sdktrace.TracerProviderOption
+	opts = append(opts, sdktrace.WithResource(res))
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return tp.Shutdown, nil
+}
+
+// TracingMiddleware starts a span per request, named after the matched
+// route so spans group the same way the Prometheus metrics do, and
+// propagates the inbound traceparent header as the span's parent.
+func TracingMiddleware() gin.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+	propagator := otel.GetTextMapPropagator()
+
+	return func(c *gin.Context) {
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+path, trace.WithAttributes(
+			attribute.String("http.method", c.Request.Method),
+			attribute.String("http.route", path),
+		))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		span.SetAttributes(attribute.Int("http.status_code", c.Writer.Status()))
+	}
+}
+
+// StartSpan starts a child span under tracerName, for instrumenting a
+// single outbound call (DB query, Kafka publish/consume, carrier HTTP
+// request) rather than a whole inbound request. Callers must End() the
+// returned span, typically via defer.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,183 @@
+// Package openapi generates the OpenAPI 3.1 document for the cart, orders,
+// shipping, admin, and webhook routes main() registers. Request body
+// schemas are generated from the dto types; the route list itself is kept
+// by hand next to main()'s route table and does not include the auth,
+// health, metrics, or docs endpoints.
+package openapi
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+
+	"order-service/dto"
+	"order-service/shipping/carriers"
+)
+
+// route describes one registered endpoint well enough to generate a
+// Path Item from it; requestType is nil for routes with no JSON body.
+type route struct {
+	method      string
+	path        string
+	tag         string
+	summary     string
+	requestType reflect.Type
+	authed      bool
+}
+
+// routes mirrors the groups registered in main(): cart, orders, shipping,
+// admin, and webhooks.
+var routes = []route{
+	{"GET", "/api/v1/cart", "cart", "Get the current user's cart", nil, true},
+	{"POST", "/api/v1/cart/items", "cart", "Add an item to the cart", reflect.TypeOf(dto.AddToCartRequest{}), true},
+	{"PUT", "/api/v1/cart/items/{itemId}", "cart", "Update a cart item", reflect.TypeOf(dto.UpdateCartItemRequest{}), true},
+	{"DELETE", "/api/v1/cart/items/{itemId}", "cart", "Remove a cart item", nil, true},
+	{"DELETE", "/api/v1/cart", "cart", "Clear the cart", nil, true},
+	{"POST", "/api/v1/cart/checkout", "cart", "Check out the cart into an order", nil, true},
+
+	{"POST", "/api/v1/orders", "orders", "Create an order", reflect.TypeOf(dto.CreateOrderRequest{}), true},
+	{"GET", "/api/v1/orders", "orders", "List the user's orders", nil, true},
+	{"GET", "/api/v1/orders/{orderId}", "orders", "Get an order", nil, true},
+	{"PUT", "/api/v1/orders/{orderId}", "orders", "Update an order", reflect.TypeOf(dto.UpdateOrderStatusRequest{}), true},
+	{"DELETE", "/api/v1/orders/{orderId}", "orders", "Cancel an order", nil, true},
+	{"POST", "/api/v1/orders/{orderId}/cancel", "orders", "Cancel an order", nil, true},
+	{"GET", "/api/v1/orders/{orderId}/status", "orders", "Get order status", nil, true},
+	{"GET", "/api/v1/orders/{orderId}/tracking", "orders", "Get order tracking", nil, true},
+	{"POST", "/api/v1/orders/{orderId}/return", "orders", "Initiate a return", nil, true},
+
+	{"GET", "/api/v1/shipping/rates", "shipping", "Rate-shop across carriers", reflect.TypeOf(carriers.RateRequest{}), true},
+	{"POST", "/api/v1/shipping/calculate", "shipping", "Calculate shipping and tax", reflect.TypeOf(dto.CalculateShippingRequest{}), true},
+	{"GET", "/api/v1/shipping/methods", "shipping", "List shipping methods", nil, true},
+	{"POST", "/api/v1/shipping/labels", "shipping", "Create a shipping label", reflect.TypeOf(carriers.LabelRequest{}), true},
+
+	{"GET", "/internal/admin/orders", "admin", "List all orders", nil, true},
+	{"GET", "/internal/admin/orders/{orderId}", "admin", "Get any order", nil, true},
+	{"PUT", "/internal/admin/orders/{orderId}/status", "admin", "Update an order's status", reflect.TypeOf(dto.UpdateOrderStatusRequest{}), true},
+	{"DELETE", "/internal/admin/orders/{orderId}", "admin", "Delete an order", nil, true},
+	{"POST", "/internal/admin/orders/bulk-cancel", "admin", "Bulk cancel orders", reflect.TypeOf(dto.BulkCancelOrdersRequest{}), true},
+	{"POST", "/internal/admin/database/reset", "admin", "Reset the database", nil, true},
+	{"GET", "/internal/admin/reports/daily", "admin", "Daily report", nil, true},
+	{"GET", "/internal/admin/reports/revenue", "admin", "Revenue report", nil, true},
+
+	{"POST", "/webhooks/payment-completed", "webhooks", "Payment completed callback", nil, false},
+	{"POST", "/webhooks/shipping-update", "webhooks", "Shipping update callback", nil, false},
+	{"POST", "/webhooks/inventory-update", "webhooks", "Inventory update callback", nil, false},
+}
+
+// Spec builds the OpenAPI 3.1 document for the service.
+func Spec() (*openapi3.T, error) {
+	gen := openapi3gen.NewGenerator(openapi3gen.UseAllExportedFields())
+
+	problemSchema, err := gen.NewSchemaRefForValue(problemDetail{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &openapi3.T{
+		OpenAPI: "3.1.0",
+		Info: &openapi3.Info{
+			Title:       "Order Service API",
+			Version:     "1.0.0",
+			Description: "Cart, order, shipping, and admin endpoints for order-service.",
+		},
+		Paths: openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas:         openapi3.Schemas{},
+			SecuritySchemes: openapi3.SecuritySchemes{},
+		},
+	}
+	doc.Components.Schemas["ProblemDetail"] = problemSchema
+	doc.Components.SecuritySchemes["bearerAuth"] = &openapi3.SecuritySchemeRef{
+		Value: openapi3.NewJWTSecurityScheme(),
+	}
+
+	for _, rt := range routes {
+		op := &openapi3.Operation{
+			Tags:    []string{rt.tag},
+			Summary: rt.summary,
+			Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{
+				Value: openapi3.NewResponse().WithDescription("Success"),
+			})),
+		}
+		op.Responses.Set("400", &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription("Validation failed").
+				WithJSONSchemaRef(openapi3.NewSchemaRef("#/components/schemas/ProblemDetail", nil)),
+		})
+		if rt.authed {
+			op.Security = &openapi3.SecurityRequirements{{"bearerAuth": {}}}
+		}
+
+		for _, param := range pathParams(rt.path) {
+			op.Parameters = append(op.Parameters, &openapi3.ParameterRef{
+				Value: openapi3.NewPathParameter(param).WithSchema(openapi3.NewStringSchema()),
+			})
+		}
+
+		if rt.requestType != nil {
+			schemaRef, err := gen.NewSchemaRefForValue(reflect.New(rt.requestType).Elem().Interface(), nil)
+			if err != nil {
+				return nil, err
+			}
+			op.RequestBody = &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().
+					WithRequired(true).
+					WithJSONSchemaRef(schemaRef),
+			}
+		}
+
+		item := doc.Paths.Value(rt.path)
+		if item == nil {
+			item = &openapi3.PathItem{}
+			doc.Paths.Set(rt.path, item)
+		}
+		item.SetOperation(rt.method, op)
+	}
+
+	return doc, nil
+}
+
+// problemDetail mirrors problem.Problem's shape for schema generation;
+// kept local so this package doesn't import the handler-facing problem
+// package just to describe its JSON shape.
+type problemDetail struct {
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Status     int    `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	Violations []struct {
+		Field  string `json:"field"`
+		Reason string `json:"reason"`
+	} `json:"violations,omitempty"`
+}
+
+// pathParams extracts the names of the OpenAPI-style {name} segments in a
+// route path (the routes above are already written with {name} in place
+// of gin's :name).
+func pathParams(ginPath string) []string {
+	var names []string
+	for _, seg := range splitSegments(ginPath) {
+		if len(seg) > 2 && seg[0] == '{' && seg[len(seg)-1] == '}' {
+			names = append(names, seg[1:len(seg)-1])
+		}
+	}
+	return names
+}
+
+func splitSegments(path string) []string {
+	var segs []string
+	start := 0
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			if i > start {
+				segs = append(segs, path[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(path) {
+		segs = append(segs, path[start:])
+	}
+	return segs
+}
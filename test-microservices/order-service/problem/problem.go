@@ -0,0 +1,90 @@
+// Package problem implements RFC 7807 (application/problem+json) error
+// responses for request validation, so a single bad field doesn't hide
+// every other violation behind gin's default single-error string.
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const contentType = "application/problem+json"
+
+// FieldViolation names one field that failed validation and why.
+type FieldViolation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Problem is an RFC 7807 problem detail document.
+type Problem struct {
+	Type       string           `json:"type"`
+	Title      string           `json:"title"`
+	Status     int              `json:"status"`
+	Detail     string           `json:"detail,omitempty"`
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+// BindJSON binds the request body into obj and, on failure, writes a
+// 400 application/problem+json response listing every violated field
+// (or a single detail message for malformed JSON). It returns whether
+// binding succeeded so the caller can return early on false.
+func BindJSON(c *gin.Context, obj interface{}) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		violations := make([]FieldViolation, 0, len(verrs))
+		for _, fe := range verrs {
+			violations = append(violations, FieldViolation{
+				Field:  fe.Field(),
+				Reason: reasonFor(fe),
+			})
+		}
+		write(c, Problem{
+			Type:       "about:blank",
+			Title:      "Validation failed",
+			Status:     http.StatusBadRequest,
+			Violations: violations,
+		})
+		return false
+	}
+
+	write(c, Problem{
+		Type:   "about:blank",
+		Title:  "Malformed request body",
+		Status: http.StatusBadRequest,
+		Detail: err.Error(),
+	})
+	return false
+}
+
+func reasonFor(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	case "len":
+		return "must be exactly " + fe.Param() + " characters"
+	default:
+		return "failed " + fe.Tag() + " validation"
+	}
+}
+
+func write(c *gin.Context, p Problem) {
+	c.Header("Content-Type", contentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
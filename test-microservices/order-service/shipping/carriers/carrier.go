@@ -0,0 +1,107 @@
+// Package carriers defines the Carrier abstraction the shipping/tracking
+// handlers use instead of hard-coded values, plus a registry that picks a
+// carrier by shipping method or rate-shops across all of them.
+package carriers
+
+import (
+	"context"
+	"time"
+)
+
+// Address is the minimal shipping address a rate or label request needs.
+type Address struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"` // ISO 3166-1 alpha-2
+}
+
+// Parcel describes the package being shipped.
+type Parcel struct {
+	WeightKg float64 `json:"weightKg"`
+	LengthCm float64 `json:"lengthCm"`
+	WidthCm  float64 `json:"widthCm"`
+	HeightCm float64 `json:"heightCm"`
+}
+
+type RateRequest struct {
+	From   Address `json:"from"`
+	To     Address `json:"to"`
+	Parcel Parcel  `json:"parcel"`
+}
+
+type Quote struct {
+	Carrier       string  `json:"carrier"`
+	Method        string  `json:"method"`
+	Price         float64 `json:"price"`
+	Currency      string  `json:"currency"`
+	EstimatedDays int     `json:"estimatedDays"`
+}
+
+type LabelRequest struct {
+	From   Address `json:"from"`
+	To     Address `json:"to"`
+	Parcel Parcel  `json:"parcel"`
+	Method string  `json:"method"`
+}
+
+// Label is a generated shipping label; Format is "PDF" or "PNG" and
+// Content holds the raw rendered bytes.
+type Label struct {
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"trackingNumber"`
+	Format         string `json:"format"`
+	Content        []byte `json:"-"`
+}
+
+type TrackingEvent struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+}
+
+type TrackingInfo struct {
+	Carrier        string          `json:"carrier"`
+	TrackingNumber string          `json:"trackingNumber"`
+	Status         string          `json:"status"`
+	Events         []TrackingEvent `json:"events"`
+}
+
+// Credentials for the real adapters live here, rather than behind their
+// own build tags, so untagged code (config loading, wiring in main) can
+// reference them regardless of which carrier_x build tags are enabled.
+type UPSCredentials struct {
+	ClientID      string
+	ClientSecret  string
+	AccountNumber string
+}
+
+type FedExCredentials struct {
+	APIKey        string
+	APISecret     string
+	AccountNumber string
+}
+
+type USPSCredentials struct {
+	UserID string
+}
+
+type DHLCredentials struct {
+	APIKey    string
+	APISecret string
+}
+
+// Carrier is implemented once per shipping provider (UPS, FedEx, USPS,
+// DHL, ...), each behind its own build tag/config flag, plus a Mock used
+// in tests and local dev.
+type Carrier interface {
+	Name() string
+	Rate(ctx context.Context, req RateRequest) ([]Quote, error)
+	CreateLabel(ctx context.Context, req LabelRequest) (Label, error)
+	Track(ctx context.Context, trackingNumber string) (TrackingInfo, error)
+	Cancel(ctx context.Context, shipmentID string) error
+}
@@ -0,0 +1,98 @@
+//go:build carrier_fedex
+
+package carriers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"order-service/observability"
+)
+
+// FedEx adapts the FedEx Ship/Rate/Track REST APIs to Carrier.
+type FedEx struct {
+	creds      FedExCredentials
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewFedEx(creds FedExCredentials) *FedEx {
+	return &FedEx{
+		creds:      creds,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    "https://apis.fedex.com",
+	}
+}
+
+func (f *FedEx) Name() string { return "FedEx" }
+
+func (f *FedEx) Rate(ctx context.Context, req RateRequest) ([]Quote, error) {
+	var quotes []Quote
+	if err := f.post(ctx, "/rate/v1/rates/quotes", req, &struct {
+		Quotes *[]Quote `json:"quotes"`
+	}{Quotes: &quotes}); err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+func (f *FedEx) CreateLabel(ctx context.Context, req LabelRequest) (Label, error) {
+	var label Label
+	if err := f.post(ctx, "/ship/v1/shipments", req, &label); err != nil {
+		return Label{}, err
+	}
+	label.Carrier = "FedEx"
+	return label, nil
+}
+
+func (f *FedEx) Track(ctx context.Context, trackingNumber string) (TrackingInfo, error) {
+	var info TrackingInfo
+	if err := f.post(ctx, "/track/v1/trackingnumbers", map[string]interface{}{"trackingNumber": trackingNumber}, &info); err != nil {
+		return TrackingInfo{}, err
+	}
+	info.Carrier = "FedEx"
+	return info, nil
+}
+
+func (f *FedEx) Cancel(ctx context.Context, shipmentID string) error {
+	return f.post(ctx, "/ship/v1/shipments/cancel", map[string]interface{}{"shipmentId": shipmentID}, &struct{}{})
+}
+
+func (f *FedEx) post(ctx context.Context, path string, body, out interface{}) error {
+	ctx, span := observability.StartSpan(ctx, "carrier.FedEx.post "+path)
+	defer span.End()
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, f.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	f.setAuth(httpReq)
+	resp, err := f.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("carriers: FedEx request to %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("carriers: FedEx request to %s failed with status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("carriers: decode FedEx response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// setAuth attaches the API key/secret and account number FedEx expects on
+// every Ship/Rate/Track call.
+func (f *FedEx) setAuth(req *http.Request) {
+	req.Header.Set("X-Api-Key", f.creds.APIKey)
+	req.Header.Set("X-Api-Secret", f.creds.APISecret)
+	req.Header.Set("X-Account-Number", f.creds.AccountNumber)
+}
@@ -0,0 +1,62 @@
+package carriers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mock is a Carrier that never calls out to a real provider; used for
+// local dev and tests so rate-shopping and label creation can be
+// exercised without credentials.
+type Mock struct {
+	NameValue     string
+	PricePerKg    float64
+	EstimatedDays int
+}
+
+func NewMock(name string, pricePerKg float64, estimatedDays int) *Mock {
+	return &Mock{NameValue: name, PricePerKg: pricePerKg, EstimatedDays: estimatedDays}
+}
+
+func (m *Mock) Name() string { return m.NameValue }
+
+func (m *Mock) Rate(ctx context.Context, req RateRequest) ([]Quote, error) {
+	weight := req.Parcel.WeightKg
+	if weight <= 0 {
+		weight = 1
+	}
+	return []Quote{{
+		Carrier:       m.NameValue,
+		Method:        "standard",
+		Price:         m.PricePerKg * weight,
+		Currency:      "USD",
+		EstimatedDays: m.EstimatedDays,
+	}}, nil
+}
+
+func (m *Mock) CreateLabel(ctx context.Context, req LabelRequest) (Label, error) {
+	return Label{
+		Carrier:        m.NameValue,
+		TrackingNumber: uuid.New().String(),
+		Format:         "PDF",
+		Content:        []byte("%PDF-1.4 mock label\n"),
+	}, nil
+}
+
+func (m *Mock) Track(ctx context.Context, trackingNumber string) (TrackingInfo, error) {
+	return TrackingInfo{
+		Carrier:        m.NameValue,
+		TrackingNumber: trackingNumber,
+		Status:         "in_transit",
+		Events: []TrackingEvent{
+			{Timestamp: time.Now().Add(-24 * time.Hour), Status: "picked_up", Description: "Package picked up"},
+			{Timestamp: time.Now(), Status: "in_transit", Description: "In transit to destination"},
+		},
+	}, nil
+}
+
+func (m *Mock) Cancel(ctx context.Context, shipmentID string) error {
+	return nil
+}
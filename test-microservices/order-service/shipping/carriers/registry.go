@@ -0,0 +1,97 @@
+package carriers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Registry selects a Carrier by shipping method, or rate-shops across
+// every registered carrier in parallel.
+type Registry struct {
+	byMethod map[string]Carrier
+	all      []Carrier
+	timeout  time.Duration
+}
+
+// NewRegistry builds a Registry from a method->carrier assignment (e.g.
+// "overnight" -> UPS, "standard" -> USPS). The order of rate shopping
+// results with equal prices is not defined.
+func NewRegistry(byMethod map[string]Carrier, timeout time.Duration) *Registry {
+	if timeout == 0 {
+		timeout = 3 * time.Second
+	}
+	seen := map[string]bool{}
+	var all []Carrier
+	for _, c := range byMethod {
+		if !seen[c.Name()] {
+			seen[c.Name()] = true
+			all = append(all, c)
+		}
+	}
+	return &Registry{byMethod: byMethod, all: all, timeout: timeout}
+}
+
+// ForMethod returns the carrier configured for a shipping method.
+func (r *Registry) ForMethod(method string) (Carrier, error) {
+	c, ok := r.byMethod[method]
+	if !ok {
+		return nil, fmt.Errorf("carriers: no carrier configured for method %q", method)
+	}
+	return c, nil
+}
+
+// ShopRates queries every registered carrier in parallel, each bounded
+// by the registry's per-provider timeout, and returns every quote that
+// came back in time sorted cheapest-first. A slow or failing carrier is
+// dropped rather than failing the whole request.
+func (r *Registry) ShopRates(ctx context.Context, req RateRequest) []Quote {
+	var (
+		mu     sync.Mutex
+		quotes []Quote
+		wg     sync.WaitGroup
+	)
+
+	for _, carrier := range r.all {
+		wg.Add(1)
+		go func(c Carrier) {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+
+			result, err := c.Rate(cctx, req)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			quotes = append(quotes, result...)
+			mu.Unlock()
+		}(carrier)
+	}
+	wg.Wait()
+
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].Price < quotes[j].Price })
+	return quotes
+}
+
+// Track asks every registered carrier until one recognizes
+// trackingNumber, since the tracking handler doesn't know which carrier
+// shipped a given order ahead of time.
+func (r *Registry) Track(ctx context.Context, trackingNumber string) (TrackingInfo, error) {
+	var lastErr error
+	for _, carrier := range r.all {
+		cctx, cancel := context.WithTimeout(ctx, r.timeout)
+		info, err := carrier.Track(cctx, trackingNumber)
+		cancel()
+		if err == nil {
+			return info, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("carriers: no carriers configured")
+	}
+	return TrackingInfo{}, fmt.Errorf("carriers: no carrier recognized tracking number %q: %w", trackingNumber, lastErr)
+}
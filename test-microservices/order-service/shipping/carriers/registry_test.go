@@ -0,0 +1,127 @@
+package carriers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeCarrier is a Carrier whose behavior is controlled per-test: it can
+// return a fixed quote, fail, or stall past the registry's timeout.
+type fakeCarrier struct {
+	name    string
+	price   float64
+	delay   time.Duration
+	rateErr error
+	tracked map[string]TrackingInfo
+}
+
+func (f *fakeCarrier) Name() string { return f.name }
+
+func (f *fakeCarrier) Rate(ctx context.Context, req RateRequest) ([]Quote, error) {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.rateErr != nil {
+		return nil, f.rateErr
+	}
+	return []Quote{{Carrier: f.name, Method: "standard", Price: f.price, Currency: "USD"}}, nil
+}
+
+func (f *fakeCarrier) CreateLabel(ctx context.Context, req LabelRequest) (Label, error) {
+	return Label{Carrier: f.name}, nil
+}
+
+func (f *fakeCarrier) Track(ctx context.Context, trackingNumber string) (TrackingInfo, error) {
+	if info, ok := f.tracked[trackingNumber]; ok {
+		return info, nil
+	}
+	return TrackingInfo{}, errors.New("not found")
+}
+
+func (f *fakeCarrier) Cancel(ctx context.Context, shipmentID string) error { return nil }
+
+func TestShopRatesDropsSlowAndFailingCarriers(t *testing.T) {
+	cheap := &fakeCarrier{name: "Cheap", price: 5}
+	slow := &fakeCarrier{name: "Slow", price: 1, delay: 50 * time.Millisecond}
+	failing := &fakeCarrier{name: "Failing", rateErr: errors.New("provider down")}
+
+	reg := NewRegistry(map[string]Carrier{
+		"a": cheap, "b": slow, "c": failing,
+	}, 10*time.Millisecond)
+
+	quotes := reg.ShopRates(context.Background(), RateRequest{})
+
+	if len(quotes) != 1 {
+		t.Fatalf("expected 1 quote (slow and failing dropped), got %d: %+v", len(quotes), quotes)
+	}
+	if quotes[0].Carrier != "Cheap" {
+		t.Fatalf("expected Cheap quote, got %q", quotes[0].Carrier)
+	}
+}
+
+func TestShopRatesSortsCheapestFirst(t *testing.T) {
+	a := &fakeCarrier{name: "A", price: 9}
+	b := &fakeCarrier{name: "B", price: 3}
+	c := &fakeCarrier{name: "C", price: 6}
+
+	reg := NewRegistry(map[string]Carrier{"a": a, "b": b, "c": c}, time.Second)
+
+	quotes := reg.ShopRates(context.Background(), RateRequest{})
+	if len(quotes) != 3 {
+		t.Fatalf("expected 3 quotes, got %d", len(quotes))
+	}
+	for i := 1; i < len(quotes); i++ {
+		if quotes[i].Price < quotes[i-1].Price {
+			t.Fatalf("quotes not sorted cheapest-first: %+v", quotes)
+		}
+	}
+}
+
+func TestForMethodReturnsConfiguredCarrier(t *testing.T) {
+	ups := &fakeCarrier{name: "UPS"}
+	reg := NewRegistry(map[string]Carrier{"overnight": ups}, time.Second)
+
+	c, err := reg.ForMethod("overnight")
+	if err != nil {
+		t.Fatalf("ForMethod: %v", err)
+	}
+	if c.Name() != "UPS" {
+		t.Fatalf("expected UPS, got %q", c.Name())
+	}
+
+	if _, err := reg.ForMethod("unknown"); err == nil {
+		t.Fatalf("expected error for unconfigured method")
+	}
+}
+
+func TestTrackFallsBackToNextCarrier(t *testing.T) {
+	notFound := &fakeCarrier{name: "NotFound", tracked: map[string]TrackingInfo{}}
+	found := &fakeCarrier{name: "Found", tracked: map[string]TrackingInfo{
+		"TRACK123": {Carrier: "Found", TrackingNumber: "TRACK123", Status: "in_transit"},
+	}}
+
+	reg := NewRegistry(map[string]Carrier{"a": notFound, "b": found}, time.Second)
+
+	info, err := reg.Track(context.Background(), "TRACK123")
+	if err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+	if info.Carrier != "Found" {
+		t.Fatalf("expected Found carrier, got %q", info.Carrier)
+	}
+}
+
+func TestTrackReturnsErrorWhenNoCarrierRecognizesNumber(t *testing.T) {
+	notFound := &fakeCarrier{name: "NotFound", tracked: map[string]TrackingInfo{}}
+	reg := NewRegistry(map[string]Carrier{"a": notFound}, time.Second)
+
+	if _, err := reg.Track(context.Background(), "UNKNOWN"); err == nil {
+		t.Fatalf("expected error when no carrier recognizes the tracking number")
+	}
+}
@@ -0,0 +1,141 @@
+//go:build carrier_ups
+
+package carriers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"order-service/observability"
+)
+
+// UPS adapts the UPS Rating/Shipping/Tracking REST APIs to Carrier.
+type UPS struct {
+	creds      UPSCredentials
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewUPS(creds UPSCredentials) *UPS {
+	return &UPS{
+		creds:      creds,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    "https://onlinetools.ups.com/api",
+	}
+}
+
+func (u *UPS) Name() string { return "UPS" }
+
+func (u *UPS) Rate(ctx context.Context, req RateRequest) ([]Quote, error) {
+	ctx, span := observability.StartSpan(ctx, "carrier.UPS.Rate")
+	defer span.End()
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.baseURL+"/rating/v1/Shop", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	u.setAuth(httpReq)
+	resp, err := u.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("carriers: UPS rate request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("carriers: UPS rate request failed with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Quotes []Quote `json:"quotes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("carriers: decode UPS rate response: %w", err)
+	}
+	return result.Quotes, nil
+}
+
+func (u *UPS) CreateLabel(ctx context.Context, req LabelRequest) (Label, error) {
+	ctx, span := observability.StartSpan(ctx, "carrier.UPS.CreateLabel")
+	defer span.End()
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Label{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.baseURL+"/shipments/v1/ship", bytes.NewReader(body))
+	if err != nil {
+		return Label{}, err
+	}
+	u.setAuth(httpReq)
+	resp, err := u.httpClient.Do(httpReq)
+	if err != nil {
+		return Label{}, fmt.Errorf("carriers: UPS label request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return Label{}, fmt.Errorf("carriers: UPS label request failed with status %d", resp.StatusCode)
+	}
+
+	var label Label
+	if err := json.NewDecoder(resp.Body).Decode(&label); err != nil {
+		return Label{}, fmt.Errorf("carriers: decode UPS label response: %w", err)
+	}
+	label.Carrier = "UPS"
+	return label, nil
+}
+
+func (u *UPS) Track(ctx context.Context, trackingNumber string) (TrackingInfo, error) {
+	ctx, span := observability.StartSpan(ctx, "carrier.UPS.Track")
+	defer span.End()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.baseURL+"/track/v1/details/"+trackingNumber, nil)
+	if err != nil {
+		return TrackingInfo{}, err
+	}
+	u.setAuth(httpReq)
+	resp, err := u.httpClient.Do(httpReq)
+	if err != nil {
+		return TrackingInfo{}, fmt.Errorf("carriers: UPS tracking request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return TrackingInfo{}, fmt.Errorf("carriers: UPS tracking request failed with status %d", resp.StatusCode)
+	}
+
+	var info TrackingInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return TrackingInfo{}, fmt.Errorf("carriers: decode UPS tracking response: %w", err)
+	}
+	info.Carrier = "UPS"
+	return info, nil
+}
+
+func (u *UPS) Cancel(ctx context.Context, shipmentID string) error {
+	ctx, span := observability.StartSpan(ctx, "carrier.UPS.Cancel")
+	defer span.End()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.baseURL+"/shipments/v1/void/"+shipmentID, nil)
+	if err != nil {
+		return err
+	}
+	u.setAuth(httpReq)
+	resp, err := u.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("carriers: UPS cancel request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("carriers: UPS cancel failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// setAuth attaches the OAuth client credentials and account number UPS
+// expects on every Rating/Shipping/Tracking call.
+func (u *UPS) setAuth(req *http.Request) {
+	req.SetBasicAuth(u.creds.ClientID, u.creds.ClientSecret)
+	req.Header.Set("AccountNumber", u.creds.AccountNumber)
+}
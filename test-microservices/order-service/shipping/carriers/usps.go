@@ -0,0 +1,97 @@
+//go:build carrier_usps
+
+package carriers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"order-service/observability"
+)
+
+// USPS adapts the USPS Web Tools API to Carrier.
+type USPS struct {
+	creds      USPSCredentials
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewUSPS(creds USPSCredentials) *USPS {
+	return &USPS{
+		creds:      creds,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    "https://secure.shippingapis.com/ShippingAPI.dll",
+	}
+}
+
+func (u *USPS) Name() string { return "USPS" }
+
+func (u *USPS) Rate(ctx context.Context, req RateRequest) ([]Quote, error) {
+	var quotes []Quote
+	if err := u.call(ctx, "RateV4", req, &struct {
+		Quotes *[]Quote `json:"quotes"`
+	}{Quotes: &quotes}); err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+func (u *USPS) CreateLabel(ctx context.Context, req LabelRequest) (Label, error) {
+	var label Label
+	if err := u.call(ctx, "eVS", req, &label); err != nil {
+		return Label{}, err
+	}
+	label.Carrier = "USPS"
+	return label, nil
+}
+
+func (u *USPS) Track(ctx context.Context, trackingNumber string) (TrackingInfo, error) {
+	var info TrackingInfo
+	if err := u.call(ctx, "TrackV2", map[string]interface{}{"trackingNumber": trackingNumber}, &info); err != nil {
+		return TrackingInfo{}, err
+	}
+	info.Carrier = "USPS"
+	return info, nil
+}
+
+// Cancel is unsupported: USPS does not allow voiding an eVS label once
+// it has been generated.
+func (u *USPS) Cancel(ctx context.Context, shipmentID string) error {
+	return fmt.Errorf("carriers: USPS does not support cancelling a generated label")
+}
+
+func (u *USPS) call(ctx context.Context, api string, body, out interface{}) error {
+	ctx, span := observability.StartSpan(ctx, "carrier.USPS."+api)
+	defer span.End()
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.baseURL+"?API="+api, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	u.setAuth(httpReq)
+	resp, err := u.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("carriers: USPS %s request: %w", api, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("carriers: USPS %s request failed with status %d", api, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("carriers: decode USPS %s response: %w", api, err)
+	}
+	return nil
+}
+
+// setAuth attaches the USPS Web Tools user ID every call must carry.
+func (u *USPS) setAuth(req *http.Request) {
+	req.Header.Set("X-USPS-UserId", u.creds.UserID)
+}
@@ -0,0 +1,47 @@
+package shipping
+
+import (
+	"os"
+	"time"
+
+	"order-service/shipping/carriers"
+)
+
+// Config describes which carrier backs each shipping method and the
+// credentials real adapters need when their build tag is enabled. With
+// no build tags enabled, every method falls back to carriers.Mock so the
+// service still runs without any carrier account.
+type Config struct {
+	RateTimeout time.Duration
+
+	UPS   carriers.UPSCredentials
+	FedEx carriers.FedExCredentials
+	USPS  carriers.USPSCredentials
+	DHL   carriers.DHLCredentials
+}
+
+// LoadConfig reads carrier credentials from ORDER_SHIPPING_* env vars.
+// Credentials are only consulted by the real adapters, which are only
+// compiled in when their carrier_x build tag is set.
+func LoadConfig() Config {
+	return Config{
+		RateTimeout: 3 * time.Second,
+		UPS: carriers.UPSCredentials{
+			ClientID:      os.Getenv("ORDER_SHIPPING_UPS_CLIENT_ID"),
+			ClientSecret:  os.Getenv("ORDER_SHIPPING_UPS_CLIENT_SECRET"),
+			AccountNumber: os.Getenv("ORDER_SHIPPING_UPS_ACCOUNT_NUMBER"),
+		},
+		FedEx: carriers.FedExCredentials{
+			APIKey:        os.Getenv("ORDER_SHIPPING_FEDEX_API_KEY"),
+			APISecret:     os.Getenv("ORDER_SHIPPING_FEDEX_API_SECRET"),
+			AccountNumber: os.Getenv("ORDER_SHIPPING_FEDEX_ACCOUNT_NUMBER"),
+		},
+		USPS: carriers.USPSCredentials{
+			UserID: os.Getenv("ORDER_SHIPPING_USPS_USER_ID"),
+		},
+		DHL: carriers.DHLCredentials{
+			APIKey:    os.Getenv("ORDER_SHIPPING_DHL_API_KEY"),
+			APISecret: os.Getenv("ORDER_SHIPPING_DHL_API_SECRET"),
+		},
+	}
+}
@@ -0,0 +1,31 @@
+// Package shipping wires the shipping/carriers package into the rest of
+// the order-service: it builds the per-method carrier registry and holds
+// the carrier credential config.
+package shipping
+
+import "order-service/shipping/carriers"
+
+// carrierOverrides lets a real adapter replace the default Mock entry
+// for its shipping method. Each carrier_x build tag's own file (e.g.
+// registry_ups.go) registers its override here from an init(), so an
+// untagged build never references the real adapter constructors at all
+// and building with -tags carrier_ups (etc.) is what links one in.
+var carrierOverrides = map[string]func(Config) carriers.Carrier{}
+
+// NewRegistry builds the per-shipping-method carrier registry. By
+// default every method is served by carriers.Mock; wiring in a real
+// provider for a given method is a deliberate, per-deployment decision
+// made by enabling that carrier's build tag, not something env vars
+// alone should flip.
+func NewRegistry(cfg Config) *carriers.Registry {
+	byMethod := map[string]carriers.Carrier{
+		"standard":  carriers.NewMock("USPS", 4.5, 6),
+		"express":   carriers.NewMock("FedEx", 9.0, 3),
+		"overnight": carriers.NewMock("UPS", 18.0, 1),
+		"pickup":    carriers.NewMock("DHL", 0, 0),
+	}
+	for method, build := range carrierOverrides {
+		byMethod[method] = build(cfg)
+	}
+	return carriers.NewRegistry(byMethod, cfg.RateTimeout)
+}
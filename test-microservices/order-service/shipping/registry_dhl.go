@@ -0,0 +1,13 @@
+//go:build carrier_dhl
+
+package shipping
+
+import "order-service/shipping/carriers"
+
+// init links the real DHL adapter into the "pickup" method slot, built
+// only when the carrier_dhl tag is set.
+func init() {
+	carrierOverrides["pickup"] = func(cfg Config) carriers.Carrier {
+		return carriers.NewDHL(cfg.DHL)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build carrier_fedex
+
+package shipping
+
+import "order-service/shipping/carriers"
+
+// init links the real FedEx adapter into the "express" method slot,
+// built only when the carrier_fedex tag is set.
+func init() {
+	carrierOverrides["express"] = func(cfg Config) carriers.Carrier {
+		return carriers.NewFedEx(cfg.FedEx)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build carrier_ups
+
+package shipping
+
+import "order-service/shipping/carriers"
+
+// init links the real UPS adapter into the "overnight" method slot,
+// built only when the carrier_ups tag is set.
+func init() {
+	carrierOverrides["overnight"] = func(cfg Config) carriers.Carrier {
+		return carriers.NewUPS(cfg.UPS)
+	}
+}
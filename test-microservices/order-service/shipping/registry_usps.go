@@ -0,0 +1,13 @@
+//go:build carrier_usps
+
+package shipping
+
+import "order-service/shipping/carriers"
+
+// init links the real USPS adapter into the "standard" method slot,
+// built only when the carrier_usps tag is set.
+func init() {
+	carrierOverrides["standard"] = func(cfg Config) carriers.Carrier {
+		return carriers.NewUSPS(cfg.USPS)
+	}
+}
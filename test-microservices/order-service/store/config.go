@@ -0,0 +1,75 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Driver identifies which GORM dialect to open.
+type Driver string
+
+const (
+	DriverMySQL    Driver = "mysql"
+	DriverPostgres Driver = "postgres"
+	DriverSQLite   Driver = "sqlite"
+)
+
+// DbNode mirrors the snow-framework [Db.Master]/[Db.Slaves] layout: one
+// node is a single DSN plus pool tuning.
+type DbNode struct {
+	DSN            string        `toml:"DSN"`
+	MaxConns       int           `toml:"MaxConns"`
+	IdleConns      int           `toml:"IdleConns"`
+	IdleTimeout    time.Duration `toml:"IdleTimeout"`
+	ConnectTimeout time.Duration `toml:"ConnectTimeout"`
+}
+
+// DbConfig holds a master node plus optional read replicas, selected by
+// Driver.
+type DbConfig struct {
+	Driver Driver   `toml:"Driver"`
+	Master DbNode   `toml:"Master"`
+	Slaves []DbNode `toml:"Slaves"`
+}
+
+type Config struct {
+	Db DbConfig `toml:"Db"`
+}
+
+// LoadConfig reads db connection settings from a TOML file if configPath
+// is non-empty, then applies ORDER_DB_* environment overrides on top so
+// deployments can avoid baking secrets into the file.
+func LoadConfig(configPath string) (Config, error) {
+	var cfg Config
+	if configPath != "" {
+		if _, err := toml.DecodeFile(configPath, &cfg); err != nil {
+			return Config{}, fmt.Errorf("store: decode config %s: %w", configPath, err)
+		}
+	}
+
+	if driver := os.Getenv("ORDER_DB_DRIVER"); driver != "" {
+		cfg.Db.Driver = Driver(driver)
+	}
+	if dsn := os.Getenv("ORDER_DB_DSN"); dsn != "" {
+		cfg.Db.Master.DSN = dsn
+	}
+	if cfg.Db.Driver == "" {
+		cfg.Db.Driver = DriverSQLite
+	}
+	if cfg.Db.Master.DSN == "" && cfg.Db.Driver == DriverSQLite {
+		cfg.Db.Master.DSN = "order-service.db"
+	}
+	if cfg.Db.Master.MaxConns == 0 {
+		cfg.Db.Master.MaxConns = 10
+	}
+	if cfg.Db.Master.IdleTimeout == 0 {
+		cfg.Db.Master.IdleTimeout = 5 * time.Minute
+	}
+	if cfg.Db.Master.ConnectTimeout == 0 {
+		cfg.Db.Master.ConnectTimeout = 10 * time.Second
+	}
+	return cfg, nil
+}
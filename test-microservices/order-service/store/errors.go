@@ -0,0 +1,16 @@
+package store
+
+import "strings"
+
+// isDuplicateKeyErr recognizes the unique-constraint violation text
+// returned by MySQL, Postgres, and SQLite so callers can treat a repeat
+// insert as "already exists" without depending on a specific driver.
+func isDuplicateKeyErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "duplicate") ||
+		strings.Contains(msg, "unique constraint") ||
+		strings.Contains(msg, "unique_violation")
+}
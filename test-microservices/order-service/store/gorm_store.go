@@ -0,0 +1,247 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"order-service/observability"
+)
+
+// gormStore is the GORM-backed Store implementation. It supports
+// MySQL/Postgres/SQLite, chosen by Config.Db.Driver.
+type gormStore struct {
+	db *gorm.DB
+}
+
+// Open connects to the database described by cfg, configures the pool,
+// and runs auto-migration for every model the service owns.
+func Open(cfg Config) (Store, error) {
+	dialector, err := dialectorFor(cfg.Db.Driver, cfg.Db.Master.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("store: open %s: %w", cfg.Db.Driver, err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("store: underlying sql.DB: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.Db.Master.MaxConns)
+	sqlDB.SetMaxIdleConns(cfg.Db.Master.IdleConns)
+	sqlDB.SetConnMaxIdleTime(cfg.Db.Master.IdleTimeout)
+
+	if err := db.AutoMigrate(&Order{}, &Cart{}, &Return{}, &WebhookEvent{}, &OutboxEvent{}); err != nil {
+		return nil, fmt.Errorf("store: auto-migrate: %w", err)
+	}
+
+	return &gormStore{db: db}, nil
+}
+
+func dialectorFor(driver Driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DriverMySQL:
+		return mysql.Open(dsn), nil
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	case DriverSQLite, "":
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("store: unsupported driver %q", driver)
+	}
+}
+
+func (s *gormStore) WithTx(ctx context.Context, fn func(Store) error) error {
+	ctx, span := observability.StartSpan(ctx, "store.WithTx")
+	defer span.End()
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&gormStore{db: tx})
+	})
+}
+
+func (s *gormStore) EnqueueEvent(ctx context.Context, event *OutboxEvent) error {
+	ctx, span := observability.StartSpan(ctx, "store.EnqueueEvent")
+	defer span.End()
+	return s.db.WithContext(ctx).Create(event).Error
+}
+
+func (s *gormStore) FetchUndelivered(ctx context.Context, limit int) ([]OutboxEvent, error) {
+	ctx, span := observability.StartSpan(ctx, "store.FetchUndelivered")
+	defer span.End()
+	var events []OutboxEvent
+	err := s.db.WithContext(ctx).
+		Where("delivered_at IS NULL").
+		Order("created_at asc").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (s *gormStore) MarkDelivered(ctx context.Context, eventID string) error {
+	ctx, span := observability.StartSpan(ctx, "store.MarkDelivered")
+	defer span.End()
+	now := time.Now()
+	return s.db.WithContext(ctx).Model(&OutboxEvent{}).
+		Where("id = ?", eventID).
+		Update("delivered_at", &now).Error
+}
+
+func (s *gormStore) Ping(ctx context.Context) error {
+	ctx, span := observability.StartSpan(ctx, "store.Ping")
+	defer span.End()
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *gormStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (s *gormStore) CreateOrder(ctx context.Context, order *Order) error {
+	ctx, span := observability.StartSpan(ctx, "store.CreateOrder")
+	defer span.End()
+	return s.db.WithContext(ctx).Create(order).Error
+}
+
+func (s *gormStore) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	ctx, span := observability.StartSpan(ctx, "store.GetOrder")
+	defer span.End()
+	var order Order
+	if err := s.db.WithContext(ctx).First(&order, "id = ?", orderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (s *gormStore) ListOrders(ctx context.Context, customerID string, offset, limit int) (Page, error) {
+	ctx, span := observability.StartSpan(ctx, "store.ListOrders")
+	defer span.End()
+	var page Page
+	q := s.db.WithContext(ctx).Model(&Order{}).Where("customer_id = ?", customerID)
+	if err := q.Count(&page.Total).Error; err != nil {
+		return Page{}, err
+	}
+	if err := q.Order("created_at desc").Offset(offset).Limit(limit).Find(&page.Orders).Error; err != nil {
+		return Page{}, err
+	}
+	return page, nil
+}
+
+func (s *gormStore) UpdateOrderStatus(ctx context.Context, orderID string, status OrderStatus) error {
+	ctx, span := observability.StartSpan(ctx, "store.UpdateOrderStatus")
+	defer span.End()
+	res := s.db.WithContext(ctx).Model(&Order{}).Where("id = ?", orderID).Update("status", status)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *gormStore) SetTrackingNumber(ctx context.Context, orderID, trackingNumber string) error {
+	ctx, span := observability.StartSpan(ctx, "store.SetTrackingNumber")
+	defer span.End()
+	res := s.db.WithContext(ctx).Model(&Order{}).Where("id = ?", orderID).Update("tracking_number", trackingNumber)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *gormStore) CancelOrder(ctx context.Context, orderID string) error {
+	return s.UpdateOrderStatus(ctx, orderID, StatusCancelled)
+}
+
+func (s *gormStore) BulkCancelOrders(ctx context.Context, orderIDs []string) (int, error) {
+	ctx, span := observability.StartSpan(ctx, "store.BulkCancelOrders")
+	defer span.End()
+	res := s.db.WithContext(ctx).Model(&Order{}).Where("id IN ?", orderIDs).Update("status", StatusCancelled)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return int(res.RowsAffected), nil
+}
+
+func (s *gormStore) GetCart(ctx context.Context, customerID string) (*Cart, error) {
+	ctx, span := observability.StartSpan(ctx, "store.GetCart")
+	defer span.End()
+	var cart Cart
+	err := s.db.WithContext(ctx).First(&cart, "customer_id = ?", customerID).Error
+	switch err {
+	case nil:
+		return &cart, nil
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+func (s *gormStore) SaveCart(ctx context.Context, cart *Cart) error {
+	ctx, span := observability.StartSpan(ctx, "store.SaveCart")
+	defer span.End()
+	return s.db.WithContext(ctx).Save(cart).Error
+}
+
+func (s *gormStore) ClearCart(ctx context.Context, customerID string) error {
+	ctx, span := observability.StartSpan(ctx, "store.ClearCart")
+	defer span.End()
+	return s.db.WithContext(ctx).Where("customer_id = ?", customerID).Delete(&Cart{}).Error
+}
+
+func (s *gormStore) CreateReturn(ctx context.Context, ret *Return) error {
+	ctx, span := observability.StartSpan(ctx, "store.CreateReturn")
+	defer span.End()
+	return s.db.WithContext(ctx).Create(ret).Error
+}
+
+func (s *gormStore) GetReturn(ctx context.Context, returnID string) (*Return, error) {
+	ctx, span := observability.StartSpan(ctx, "store.GetReturn")
+	defer span.End()
+	var ret Return
+	if err := s.db.WithContext(ctx).First(&ret, "id = ?", returnID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (s *gormStore) MarkProcessed(ctx context.Context, eventID, source string) (bool, error) {
+	ctx, span := observability.StartSpan(ctx, "store.MarkProcessed")
+	defer span.End()
+	err := s.db.WithContext(ctx).Create(&WebhookEvent{ID: eventID, Source: source}).Error
+	if err == nil {
+		return false, nil
+	}
+	// A primary-key conflict means this event was already recorded.
+	if isDuplicateKeyErr(err) {
+		return true, nil
+	}
+	return false, err
+}
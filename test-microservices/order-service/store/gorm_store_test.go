@@ -0,0 +1,190 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newTestStore(t *testing.T) Store {
+	t.Helper()
+	// MaxConns: 1 keeps every statement on the same connection so the
+	// in-memory database isn't dropped between them; sqlite only keeps
+	// it alive while at least one connection holds it open.
+	db, err := Open(Config{Db: DbConfig{Driver: DriverSQLite, Master: DbNode{DSN: "file::memory:?cache=shared", MaxConns: 1, IdleConns: 1}}})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newOrder(customerID string) *Order {
+	return &Order{
+		ID:              uuid.New().String(),
+		CustomerID:      customerID,
+		Items:           []OrderItem{{ProductID: "p1", Quantity: 2, Price: 9.99}},
+		Total:           19.98,
+		Status:          StatusPending,
+		ShippingAddress: "123 Main St",
+	}
+}
+
+func TestCreateAndGetOrder(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	order := newOrder("cust-1")
+	if err := s.CreateOrder(ctx, order); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	got, err := s.GetOrder(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if got.CustomerID != "cust-1" || len(got.Items) != 1 || got.Items[0].ProductID != "p1" {
+		t.Fatalf("unexpected order: %+v", got)
+	}
+}
+
+func TestGetOrderNotFound(t *testing.T) {
+	s := newTestStore(t)
+	if _, err := s.GetOrder(context.Background(), "missing"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	order := newOrder("cust-1")
+	if err := s.CreateOrder(ctx, order); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if err := s.UpdateOrderStatus(ctx, order.ID, StatusConfirmed); err != nil {
+		t.Fatalf("UpdateOrderStatus: %v", err)
+	}
+	got, err := s.GetOrder(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if got.Status != StatusConfirmed {
+		t.Fatalf("expected status confirmed, got %q", got.Status)
+	}
+
+	if err := s.UpdateOrderStatus(ctx, "missing", StatusConfirmed); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound updating missing order, got %v", err)
+	}
+}
+
+func TestBulkCancelOrders(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	a, b, c := newOrder("cust-1"), newOrder("cust-1"), newOrder("cust-1")
+	for _, o := range []*Order{a, b, c} {
+		if err := s.CreateOrder(ctx, o); err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+	}
+
+	n, err := s.BulkCancelOrders(ctx, []string{a.ID, b.ID, "missing-id"})
+	if err != nil {
+		t.Fatalf("BulkCancelOrders: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 orders cancelled, got %d", n)
+	}
+
+	gotA, _ := s.GetOrder(ctx, a.ID)
+	gotC, _ := s.GetOrder(ctx, c.ID)
+	if gotA.Status != StatusCancelled {
+		t.Fatalf("expected order a cancelled, got %q", gotA.Status)
+	}
+	if gotC.Status != StatusPending {
+		t.Fatalf("expected order c untouched, got %q", gotC.Status)
+	}
+}
+
+func TestListOrdersPagination(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if err := s.CreateOrder(ctx, newOrder("cust-1")); err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+	}
+	// Belongs to a different customer; must not appear in cust-1's page.
+	if err := s.CreateOrder(ctx, newOrder("cust-2")); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	page, err := s.ListOrders(ctx, "cust-1", 0, 2)
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if page.Total != 5 {
+		t.Fatalf("expected total 5, got %d", page.Total)
+	}
+	if len(page.Orders) != 2 {
+		t.Fatalf("expected page size 2, got %d", len(page.Orders))
+	}
+
+	page2, err := s.ListOrders(ctx, "cust-1", 2, 2)
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if len(page2.Orders) != 2 {
+		t.Fatalf("expected second page size 2, got %d", len(page2.Orders))
+	}
+	if page.Orders[0].ID == page2.Orders[0].ID {
+		t.Fatalf("expected different orders across pages")
+	}
+}
+
+func TestMarkProcessedDedupesWebhookEvents(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	alreadyProcessed, err := s.MarkProcessed(ctx, "evt-1", "payment")
+	if err != nil {
+		t.Fatalf("MarkProcessed: %v", err)
+	}
+	if alreadyProcessed {
+		t.Fatalf("expected first delivery to not be already processed")
+	}
+
+	alreadyProcessed, err = s.MarkProcessed(ctx, "evt-1", "payment")
+	if err != nil {
+		t.Fatalf("MarkProcessed (retry): %v", err)
+	}
+	if !alreadyProcessed {
+		t.Fatalf("expected retried delivery to be recognized as already processed")
+	}
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	order := newOrder("cust-1")
+	wantErr := context.Canceled
+	err := s.WithTx(ctx, func(tx Store) error {
+		if err := tx.CreateOrder(ctx, order); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("expected WithTx to surface the fn error, got %v", err)
+	}
+
+	if _, err := s.GetOrder(ctx, order.ID); err != ErrNotFound {
+		t.Fatalf("expected order creation to be rolled back, got %v", err)
+	}
+}
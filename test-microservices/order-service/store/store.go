@@ -0,0 +1,151 @@
+// Package store defines the persistence abstraction for the order-service.
+// Handlers depend only on the Store interface so the backing database
+// (MySQL/Postgres/SQLite) can be swapped via config without touching
+// business logic.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound      = errors.New("store: record not found")
+	ErrAlreadyExists = errors.New("store: record already exists")
+)
+
+type OrderStatus string
+
+const (
+	StatusPending    OrderStatus = "pending"
+	StatusConfirmed  OrderStatus = "confirmed"
+	StatusProcessing OrderStatus = "processing"
+	StatusShipped    OrderStatus = "shipped"
+	StatusDelivered  OrderStatus = "delivered"
+	StatusCancelled  OrderStatus = "cancelled"
+)
+
+type OrderItem struct {
+	ProductID string  `json:"productId" gorm:"column:product_id"`
+	Quantity  int     `json:"quantity"`
+	Price     float64 `json:"price"`
+}
+
+type Order struct {
+	ID              string      `json:"id" gorm:"primaryKey"`
+	CustomerID      string      `json:"customerId" gorm:"index"`
+	Items           []OrderItem `json:"items" gorm:"serializer:json"`
+	Total           float64     `json:"total"`
+	Status          OrderStatus `json:"status"`
+	ShippingAddress string      `json:"shippingAddress"`
+	TrackingNumber  string      `json:"trackingNumber"`
+	CreatedAt       time.Time   `json:"createdAt"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+}
+
+type CartItem struct {
+	ProductID string `json:"productId" gorm:"column:product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+type Cart struct {
+	ID         string     `json:"id" gorm:"primaryKey"`
+	CustomerID string     `json:"customerId" gorm:"uniqueIndex"`
+	Items      []CartItem `json:"items" gorm:"serializer:json"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+type Return struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	OrderID   string    `json:"orderId" gorm:"index"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookEvent records a processed webhook delivery so a retried callback
+// with the same ID can be recognized and skipped.
+type WebhookEvent struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	Source      string    `json:"source"`
+	ReceivedAt  time.Time `json:"receivedAt"`
+}
+
+// OutboxEvent is a domain event staged in the same transaction as the
+// state change it describes. A background worker publishes it to Kafka
+// and marks it delivered, giving an at-least-once delivery guarantee
+// without a distributed transaction.
+type OutboxEvent struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	Topic       string    `json:"topic"`
+	Type        string    `json:"type"`
+	Subject     string    `json:"subject"`
+	Payload     []byte    `json:"payload"`
+	CreatedAt   time.Time `json:"createdAt"`
+	DeliveredAt *time.Time `json:"deliveredAt"`
+}
+
+// Page describes a customer's order history slice.
+type Page struct {
+	Orders []Order
+	Total  int64
+}
+
+// OrderStore persists orders and their status transitions.
+type OrderStore interface {
+	CreateOrder(ctx context.Context, order *Order) error
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+	ListOrders(ctx context.Context, customerID string, offset, limit int) (Page, error)
+	UpdateOrderStatus(ctx context.Context, orderID string, status OrderStatus) error
+	SetTrackingNumber(ctx context.Context, orderID, trackingNumber string) error
+	CancelOrder(ctx context.Context, orderID string) error
+	BulkCancelOrders(ctx context.Context, orderIDs []string) (int, error)
+}
+
+// CartStore persists the single active cart per customer.
+type CartStore interface {
+	GetCart(ctx context.Context, customerID string) (*Cart, error)
+	SaveCart(ctx context.Context, cart *Cart) error
+	ClearCart(ctx context.Context, customerID string) error
+}
+
+// ReturnStore persists return requests raised against an order.
+type ReturnStore interface {
+	CreateReturn(ctx context.Context, ret *Return) error
+	GetReturn(ctx context.Context, returnID string) (*Return, error)
+}
+
+// WebhookEventStore records webhook deliveries for idempotent processing.
+type WebhookEventStore interface {
+	MarkProcessed(ctx context.Context, eventID, source string) (alreadyProcessed bool, err error)
+}
+
+// OutboxStore stages domain events for the outbox worker and lets it
+// claim and retire them once published.
+type OutboxStore interface {
+	EnqueueEvent(ctx context.Context, event *OutboxEvent) error
+	FetchUndelivered(ctx context.Context, limit int) ([]OutboxEvent, error)
+	MarkDelivered(ctx context.Context, eventID string) error
+}
+
+// Store aggregates every persistence capability the order-service needs.
+// Handlers take a Store rather than individual sub-interfaces so main.go
+// only has one dependency to construct and pass around.
+type Store interface {
+	OrderStore
+	CartStore
+	ReturnStore
+	WebhookEventStore
+	OutboxStore
+
+	// WithTx runs fn against a Store scoped to a single transaction, so a
+	// state change and its outbox event commit or roll back together.
+	WithTx(ctx context.Context, fn func(Store) error) error
+	// Ping verifies the underlying connection is alive; used by the
+	// readiness probe.
+	Ping(ctx context.Context) error
+	// Close releases the underlying connection pool.
+	Close() error
+}